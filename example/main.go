@@ -64,10 +64,10 @@ func main() {
 
 	numbers := []string{"uno", "dos", "tres"}
 
-	// 1: One
-	// 2: Two
-	// 3: Three
 	// Choose a number:
+	// > One
+	//   Two
+	//   Three
 	var chosenFoo string
 	err = interact.NewInteraction(
 		"Choose a number",
@@ -81,10 +81,10 @@ func main() {
 
 	fmt.Println(chosenFoo)
 
-	// 1: One
-	// 2: Two
-	// 3: Three
-	// Choose a number (2):
+	// Choose a number:
+	//   One
+	// > Two
+	//   Three
 	chosenFooWithDefault := "dos"
 	err = interact.NewInteraction(
 		"Choose a number",
@@ -98,11 +98,11 @@ func main() {
 
 	fmt.Println(chosenFooWithDefault)
 
-	// 1. One
-	// 2. Two
-	// 3. Three
-	// 4. none
-	// Choose a number (4):
+	// Choose a number:
+	//   One
+	//   Two
+	//   Three
+	// > none
 	var chosenFooOptional *string
 	err = interact.NewInteraction(
 		"Choose a number",
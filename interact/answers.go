@@ -0,0 +1,294 @@
+package interact
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Answers holds pre-determined answers to interactions, keyed by their Name,
+// e.g. to run a tool non-interactively in CI.
+//
+// Each answer is parsed and validated just as if the user had entered it,
+// except that bools may also be given as accepted by strconv.ParseBool, e.g.
+// "true". Lists of answers are used for multiple choices. Choices are matched by their
+// Display, or by their Value as formatted by fmt.Sprint.
+type Answers map[string]interface{}
+
+// ErrAnswerRequired is returned by Resolve when an interaction with a
+// RequiredDestination has a blank answer.
+var ErrAnswerRequired = errors.New("an answer is required")
+
+// LoadAnswers reads answers from a JSON or YAML file.
+//
+// Nested objects are flattened, joining their keys with a ".", so that
+//
+//	database:
+//	  host: localhost
+//
+// is the answer to the interaction named "database.host".
+func LoadAnswers(path string) (Answers, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseAnswers(data)
+}
+
+// ParseAnswers parses answers from JSON or YAML, as with LoadAnswers.
+func ParseAnswers(data []byte) (Answers, error) {
+	// JSON is a subset of YAML, so this handles both
+	var raw map[string]interface{}
+	err := yaml.Unmarshal(data, &raw)
+	if err != nil {
+		return nil, err
+	}
+
+	answers := Answers{}
+	answers.flatten("", raw)
+
+	return answers, nil
+}
+
+func (answers Answers) flatten(prefix string, raw map[string]interface{}) {
+	for key, val := range raw {
+		name := prefix + key
+
+		if nested, ok := val.(map[string]interface{}); ok {
+			answers.flatten(name+".", nested)
+			continue
+		}
+
+		answers[name] = val
+	}
+}
+
+// answer returns the interaction's answer from its Answers, if it has one.
+func (interaction Interaction) answer() (interface{}, bool) {
+	if interaction.Name == "" || interaction.Answers == nil {
+		return nil, false
+	}
+
+	answer, found := interaction.Answers[interaction.Name]
+	return answer, found
+}
+
+// resolveAnswer resolves dst from the given answer without prompting.
+func (interaction Interaction) resolveAnswer(dst interface{}, answer interface{}) error {
+	err := interaction.resolveAnswerLines(dst, answer)
+	if err != nil {
+		return fmt.Errorf("answer for %s: %w", interaction.Name, err)
+	}
+
+	return nil
+}
+
+func (interaction Interaction) resolveAnswerLines(dst interface{}, answer interface{}) error {
+	lines, err := interaction.answerLines(answer)
+	if err != nil {
+		return err
+	}
+
+	if len(interaction.Choices) > 0 {
+		if required, ok := dst.(RequiredDestination); ok {
+			dst = required.Destination
+		}
+
+		if interaction.isMultipleChoice(dst) {
+			return interaction.answerChoices(dst, lines)
+		}
+	}
+
+	if len(lines) != 1 {
+		return fmt.Errorf("expected a single answer, got %d", len(lines))
+	}
+
+	if len(interaction.Choices) > 0 {
+		return interaction.answerChoice(dst, lines[0])
+	}
+
+	_, _, err = interaction.readValid(dst, &answerUser{line: boolAnswer(dst, lines[0])}, "")
+	return err
+}
+
+// boolAnswer converts a bool given as accepted by strconv.ParseBool, e.g.
+// "true" as flags and environment variables usually give them, into the
+// answer the user would have entered, if dst is a bool.
+func boolAnswer(dst interface{}, line string) string {
+	if required, ok := dst.(RequiredDestination); ok {
+		dst = required.Destination
+	}
+
+	if _, ok := dst.(*bool); !ok {
+		return line
+	}
+
+	value, err := strconv.ParseBool(line)
+	if err != nil {
+		return line
+	}
+
+	if value {
+		return "yes"
+	}
+
+	return "no"
+}
+
+// answerChoice sets dst to the value of the choice matching line.
+func (interaction Interaction) answerChoice(dst interface{}, line string) error {
+	num, err := interaction.matchChoice(line)
+	if err != nil {
+		return err
+	}
+
+	dstVal := reflect.ValueOf(dst)
+
+	choiceVal, err := interaction.choiceValue(num, dstVal.Type().Elem())
+	if err != nil {
+		return err
+	}
+
+	if interaction.Validate != nil {
+		err := interaction.Validate(choiceVal.Interface())
+		if err != nil {
+			return err
+		}
+	}
+
+	dstVal.Elem().Set(choiceVal)
+
+	return nil
+}
+
+// answerChoices sets dst to the values of the choices matching lines.
+func (interaction Interaction) answerChoices(dst interface{}, lines []string) error {
+	nums := make([]int, len(lines))
+	for i, line := range lines {
+		num, err := interaction.matchChoice(line)
+		if err != nil {
+			return err
+		}
+
+		nums[i] = num
+	}
+
+	dstVal := reflect.ValueOf(dst)
+
+	chosen := interaction.choiceValues(dstVal.Type().Elem(), nums)
+
+	if interaction.Validate != nil {
+		err := interaction.Validate(chosen.Interface())
+		if err != nil {
+			return err
+		}
+	}
+
+	dstVal.Elem().Set(chosen)
+
+	return nil
+}
+
+// matchChoice returns the number of the choice whose Display or Value matches
+// line.
+func (interaction Interaction) matchChoice(line string) (int, error) {
+	for i, choice := range interaction.Choices {
+		if choice.Display == line {
+			return i + 1, nil
+		}
+	}
+
+	for i, choice := range interaction.Choices {
+		if choice.Value != nil && fmt.Sprint(choice.Value) == line {
+			return i + 1, nil
+		}
+	}
+
+	return 0, fmt.Errorf("no choice matching %q", line)
+}
+
+// answerLines converts an answer into the lines the user would have entered.
+// Lists become one line per element.
+func (interaction Interaction) answerLines(answer interface{}) ([]string, error) {
+	list, isList := answer.([]interface{})
+	if !isList {
+		line, err := interaction.answerLine(answer)
+		if err != nil {
+			return nil, err
+		}
+
+		return []string{line}, nil
+	}
+
+	lines := make([]string, len(list))
+	for i, elem := range list {
+		line, err := interaction.answerLine(elem)
+		if err != nil {
+			return nil, err
+		}
+
+		lines[i] = line
+	}
+
+	return lines, nil
+}
+
+func (interaction Interaction) answerLine(answer interface{}) (string, error) {
+	switch v := answer.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case bool:
+		// bool destinations accept this too; see boolAnswer
+		return strconv.FormatBool(v), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case uint64:
+		return strconv.FormatUint(v, 10), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case time.Time:
+		return v.Format(interaction.timeLayout()), nil
+	default:
+		return "", fmt.Errorf("unsupported answer: %v", answer)
+	}
+}
+
+// answerUser is a userIO that enters a single line of input, as given by an
+// answer.
+type answerUser struct {
+	line string
+	read bool
+}
+
+func (u *answerUser) WriteLine(line string) error {
+	return nil
+}
+
+func (u *answerUser) ReadLine(prompt string) (string, error) {
+	if u.read {
+		// asked again after a blank answer to a required destination
+		return "", ErrAnswerRequired
+	}
+
+	u.read = true
+
+	return u.line, nil
+}
+
+func (u *answerUser) ReadPassword(prompt string) (string, error) {
+	return u.ReadLine(prompt)
+}
+
+func (u *answerUser) ReadLines(prompt string) (string, error) {
+	return u.ReadLine(prompt)
+}
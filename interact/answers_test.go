@@ -0,0 +1,232 @@
+package interact_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+
+	"github.com/vito/go-interact/interact"
+)
+
+var _ = Describe("Answering from an answer file", func() {
+	var (
+		output  *gbytes.Buffer
+		answers interact.Answers
+	)
+
+	BeforeEach(func() {
+		output = gbytes.NewBuffer()
+
+		var err error
+		answers, err = interact.ParseAnswers([]byte(`
+name: web
+port: 8080
+debug: true
+timeout: 1m30s
+password: hunter2
+blank: ""
+region: Europe
+size: l
+zones: [1, "Three"]
+database:
+  host: db.example.com
+`))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	resolve := func(name string, dst interface{}, choices ...interact.Choice) error {
+		interaction := interact.NewInteraction("some prompt", choices...)
+		interaction.Name = name
+		interaction.Answers = answers
+		interaction.Input = new(errReader)
+		interaction.Output = output
+
+		return interaction.Resolve(dst)
+	}
+
+	It("resolves from the answer without prompting", func() {
+		var name string
+		Expect(resolve("name", &name)).To(Succeed())
+		Expect(name).To(Equal("web"))
+
+		var port uint16
+		Expect(resolve("port", &port)).To(Succeed())
+		Expect(port).To(Equal(uint16(8080)))
+
+		var debug bool
+		Expect(resolve("debug", &debug)).To(Succeed())
+		Expect(debug).To(BeTrue())
+
+		var timeout time.Duration
+		Expect(resolve("timeout", &timeout)).To(Succeed())
+		Expect(timeout).To(Equal(90 * time.Second))
+
+		var password interact.Password
+		Expect(resolve("password", &password)).To(Succeed())
+		Expect(password).To(Equal(interact.Password("hunter2")))
+
+		Expect(output.Contents()).To(BeEmpty())
+	})
+
+	It("parses bool answers as typed for destinations other than bools", func() {
+		var debug string
+		Expect(resolve("debug", &debug)).To(Succeed())
+		Expect(debug).To(Equal("true"))
+	})
+
+	It("flattens nested answers", func() {
+		var host string
+		Expect(resolve("database.host", &host)).To(Succeed())
+		Expect(host).To(Equal("db.example.com"))
+	})
+
+	It("keeps the default when the answer is blank", func() {
+		name := "default"
+		Expect(resolve("blank", &name)).To(Succeed())
+		Expect(name).To(Equal("default"))
+	})
+
+	It("returns an error when the answer is blank and required", func() {
+		name := "default"
+		err := resolve("blank", interact.Required(&name))
+		Expect(err).To(MatchError(interact.ErrAnswerRequired))
+		Expect(err).To(MatchError("answer for blank: an answer is required"))
+		Expect(name).To(Equal("default"))
+	})
+
+	It("returns an error when the answer cannot be parsed", func() {
+		var port uint8
+		err := resolve("port", &port)
+		Expect(err).To(MatchError(interact.ErrOutOfRange))
+		Expect(err).To(MatchError("answer for port: out of range"))
+	})
+
+	It("returns an error when the answer is not valid", func() {
+		interaction := interact.NewInteraction("some prompt")
+		interaction.Name = "name"
+		interaction.Answers = answers
+		interaction.Validate = func(value interface{}) error {
+			return errors.New("name taken")
+		}
+
+		name := "default"
+		Expect(interaction.Resolve(&name)).To(MatchError("answer for name: name taken"))
+		Expect(name).To(Equal("default"))
+	})
+
+	It("prompts as usual when there is no answer", func() {
+		var name string
+		err := resolve("missing", &name)
+		Expect(err).To(HaveOccurred())
+		Expect(output).To(gbytes.Say(`some prompt \(\): `))
+	})
+
+	Context("when the interaction has choices", func() {
+		It("matches the choice by its display", func() {
+			var region string
+			err := resolve("region", &region,
+				interact.Choice{Display: "United States", Value: "us-east-1"},
+				interact.Choice{Display: "Europe", Value: "eu-west-1"},
+			)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(region).To(Equal("eu-west-1"))
+		})
+
+		It("matches the choice by its value", func() {
+			var size string
+			err := resolve("size", &size,
+				interact.Choice{Display: "Small", Value: "s"},
+				interact.Choice{Display: "Large", Value: "l"},
+			)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(size).To(Equal("l"))
+		})
+
+		It("returns an error when no choice matches", func() {
+			var name string
+			err := resolve("name", &name,
+				interact.Choice{Display: "API", Value: "api"},
+			)
+			Expect(err).To(MatchError(`answer for name: no choice matching "web"`))
+		})
+
+		It("matches each of a list of choices when selecting multiple", func() {
+			var zones []int
+			err := resolve("zones", &zones,
+				interact.Choice{Display: "One", Value: 1},
+				interact.Choice{Display: "Two", Value: 2},
+				interact.Choice{Display: "Three", Value: 3},
+			)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(zones).To(Equal([]int{1, 3}))
+		})
+	})
+
+	Describe("loading answers from a file", func() {
+		It("parses JSON", func() {
+			path := filepath.Join(GinkgoT().TempDir(), "answers.json")
+			err := os.WriteFile(path, []byte(`{"name": "api", "database": {"port": 5432}}`), 0644)
+			Expect(err).ToNot(HaveOccurred())
+
+			answers, err := interact.LoadAnswers(path)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(answers).To(Equal(interact.Answers{
+				"name":          "api",
+				"database.port": 5432,
+			}))
+		})
+	})
+
+	Describe("answering a struct", func() {
+		It("finds each field's answer by its name", func() {
+			var config serverConfig
+
+			answers, err := interact.ParseAnswers([]byte(`
+Name: web
+Region: eu-west-1
+Zones: [1, 2]
+Timeout: 5s
+Size: Small
+Database:
+  Host: db.example.com
+  Port: 5432
+  Password: hunter2
+`))
+			Expect(err).ToNot(HaveOccurred())
+
+			err = interact.ResolveStruct(&config,
+				interact.WithAnswers(answers),
+				interact.WithInput(new(errReader)),
+				interact.WithOutput(output),
+			)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(config).To(Equal(serverConfig{
+				Name:    "web",
+				Region:  "eu-west-1",
+				Zones:   []int{1, 2},
+				Timeout: 5 * time.Second,
+				Size:    "s",
+				Database: databaseConfig{
+					Host:     "db.example.com",
+					Port:     5432,
+					Password: "hunter2",
+				},
+			}))
+
+			Expect(output.Contents()).To(BeEmpty())
+		})
+	})
+})
+
+// errReader fails any attempt to read from it, to ensure nothing is asked.
+type errReader struct{}
+
+func (errReader) Read([]byte) (int, error) {
+	return 0, errors.New("should not be read")
+}
@@ -0,0 +1,46 @@
+package interact
+
+// TypedChoice is a Choice whose value is known to be of type T, for use with
+// Choose.
+type TypedChoice[T any] struct {
+	Display string
+	Value   T
+}
+
+// Ask resolves a value of type T, showing def as the default, and returns
+// the value entered by the user.
+//
+// Any type supported by Resolve may be used for T. For example:
+//
+//	port, err := interact.Ask("Port", 8080)
+func Ask[T any](prompt string, def T, opts ...Option) (T, error) {
+	interaction := NewInteraction(prompt)
+	interaction.apply(opts)
+
+	answer := def
+	err := interaction.Resolve(&answer)
+	return answer, err
+}
+
+// Choose asks the user to select one of the given choices, and returns its
+// value.
+//
+// Unlike using Resolve with a set of Choices, the compiler guarantees that
+// the choices' values are assignable to the result. There is no default; a
+// choice must be selected.
+func Choose[T any](prompt string, choices []TypedChoice[T], opts ...Option) (T, error) {
+	untyped := make([]Choice, len(choices))
+	for i, choice := range choices {
+		untyped[i] = Choice{
+			Display: choice.Display,
+			Value:   choice.Value,
+		}
+	}
+
+	interaction := NewInteraction(prompt, untyped...)
+	interaction.apply(opts)
+
+	var answer T
+	err := interaction.Resolve(Required(&answer))
+	return answer, err
+}
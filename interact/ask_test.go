@@ -0,0 +1,118 @@
+package interact_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+
+	"github.com/vito/go-interact/interact"
+)
+
+var _ = Describe("Asking for typed values", func() {
+	var (
+		input  *bytes.Buffer
+		output *gbytes.Buffer
+	)
+
+	BeforeEach(func() {
+		input = new(bytes.Buffer)
+		output = gbytes.NewBuffer()
+	})
+
+	Describe("Ask", func() {
+		It("returns the value entered", func() {
+			input.WriteString("443\n")
+
+			port, err := interact.Ask("Port", uint16(8080), interact.WithInput(input), interact.WithOutput(output))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(port).To(Equal(uint16(443)))
+
+			Expect(output.Contents()).To(Equal([]byte("Port (8080): 443\n")))
+		})
+
+		It("returns the default when nothing is entered", func() {
+			input.WriteString("\n")
+
+			name, err := interact.Ask("Name", "bob", interact.WithInput(input), interact.WithOutput(output))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(name).To(Equal("bob"))
+		})
+
+		It("validates the value using its type", func() {
+			input.WriteString("\nalice\n")
+
+			name, err := interact.Ask("Name", "",
+				interact.WithInput(input),
+				interact.WithOutput(output),
+				interact.WithValidate(func(name string) error {
+					if name == "" {
+						return errors.New("must not be blank")
+					}
+
+					return nil
+				}),
+			)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(name).To(Equal("alice"))
+
+			Expect(output.Contents()).To(Equal([]byte("Name (): \ninvalid input (must not be blank)\nName (): alice\n")))
+		})
+
+		It("returns errors from Resolve", func() {
+			_, err := interact.Ask("Name", "bob", interact.WithInput(input), interact.WithOutput(output))
+			Expect(err).To(Equal(io.EOF))
+		})
+	})
+
+	Describe("Choose", func() {
+		type region struct {
+			name string
+		}
+
+		var regions []interact.TypedChoice[region]
+
+		BeforeEach(func() {
+			regions = []interact.TypedChoice[region]{
+				{Display: "US East", Value: region{"us-east-1"}},
+				{Display: "EU West", Value: region{"eu-west-1"}},
+			}
+		})
+
+		It("returns the value of the chosen choice", func() {
+			input.WriteString("2\n")
+
+			chosen, err := interact.Choose("Region", regions, interact.WithInput(input), interact.WithOutput(output))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(chosen).To(Equal(region{"eu-west-1"}))
+
+			Expect(output.Contents()).To(Equal([]byte("1: US East\n2: EU West\nRegion: 2\n")))
+		})
+
+		It("requires a selection", func() {
+			input.WriteString("\n1\n")
+
+			chosen, err := interact.Choose("Region", regions, interact.WithInput(input), interact.WithOutput(output))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(chosen).To(Equal(region{"us-east-1"}))
+
+			Expect(output.Contents()).To(Equal([]byte("1: US East\n2: EU West\nRegion: \nRegion: 1\n")))
+		})
+
+		It("does not choose a choice holding the zero value by default", func() {
+			input.WriteString("\n2\n")
+
+			chosen, err := interact.Choose("Replicas", []interact.TypedChoice[int]{
+				{Display: "None", Value: 0},
+				{Display: "Three", Value: 3},
+			}, interact.WithInput(input), interact.WithOutput(output))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(chosen).To(Equal(3))
+
+			Expect(output.Contents()).To(Equal([]byte("1: None\n2: Three\nReplicas: \nReplicas: 2\n")))
+		})
+	})
+})
@@ -0,0 +1,291 @@
+package interact
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+)
+
+// CompleteWords returns a completer offering each of the given words which
+// starts with the text before the cursor.
+func CompleteWords(words ...string) func(line string, pos int) []string {
+	return func(line string, pos int) []string {
+		prefix := line[:pos]
+
+		var matches []string
+		for _, word := range words {
+			if strings.HasPrefix(word, prefix) {
+				matches = append(matches, word)
+			}
+		}
+
+		return matches
+	}
+}
+
+// CompleteChoices returns a completer offering the Display of each of the
+// given choices, e.g. to suggest common answers without limiting the answer
+// to them.
+func CompleteChoices(choices ...Choice) func(line string, pos int) []string {
+	displays := make([]string, len(choices))
+	for i, choice := range choices {
+		displays[i] = choice.Display
+	}
+
+	return CompleteWords(displays...)
+}
+
+// CompletePaths is a completer offering the files and directories matching
+// the path before the cursor. Directories are completed with a trailing
+// separator, and hidden files are only offered once a "." is typed.
+func CompletePaths(line string, pos int) []string {
+	dir, base := filepath.Split(line[:pos])
+
+	readDir := dir
+	if readDir == "" {
+		readDir = "."
+	}
+
+	entries, err := os.ReadDir(readDir)
+	if err != nil {
+		return nil
+	}
+
+	var matches []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasPrefix(name, base) {
+			continue
+		}
+
+		if strings.HasPrefix(name, ".") && !strings.HasPrefix(base, ".") {
+			continue
+		}
+
+		path := dir + name
+
+		info, err := os.Stat(filepath.Join(readDir, name))
+		if err == nil && info.IsDir() {
+			path += string(filepath.Separator)
+		}
+
+		matches = append(matches, path)
+	}
+
+	return matches
+}
+
+// completion completes the line being edited on a terminal when Tab is
+// pressed. If there are several candidates, the line is completed as far as
+// they agree, and they are listed below it.
+//
+// It sits between the terminal and its input and output, so that the list can
+// be drawn once the terminal has drawn the completed line.
+type completion struct {
+	complete func(line string, pos int) []string
+
+	reader io.Reader
+	writer io.Writer
+
+	prompt        string
+	width, height int
+
+	// pending is input read after a tab, which is held back until the
+	// terminal has handled the tab
+	pending []byte
+	readErr error
+
+	// list is drawn after the terminal's next write
+	list string
+
+	// shown is true if a list is drawn below the line
+	shown bool
+}
+
+func newCompletion(complete func(string, int) []string, reader io.Reader, writer io.Writer, width, height int) *completion {
+	if width < 1 {
+		width = 1
+	}
+
+	return &completion{
+		complete: complete,
+
+		reader: reader,
+		writer: writer,
+
+		width:  width,
+		height: height,
+	}
+}
+
+// Read ends each read after a tab, so that the terminal draws the completed
+// line before handling any keys typed after it.
+func (c *completion) Read(p []byte) (int, error) {
+	if len(c.pending) == 0 && c.readErr == nil {
+		buf := make([]byte, len(p))
+		n, err := c.reader.Read(buf)
+		c.pending, c.readErr = buf[:n], err
+	}
+
+	end := len(c.pending)
+	if i := bytes.IndexByte(c.pending, '\t'); i != -1 {
+		end = i + 1
+	}
+
+	n := copy(p, c.pending[:end])
+	c.pending = c.pending[n:]
+
+	if len(c.pending) > 0 {
+		return n, nil
+	}
+
+	err := c.readErr
+	c.readErr = nil
+
+	return n, err
+}
+
+func (c *completion) Write(p []byte) (int, error) {
+	n, err := c.writer.Write(p)
+	if err != nil || c.list == "" {
+		return n, err
+	}
+
+	_, err = io.WriteString(c.writer, c.list)
+	c.list = ""
+	c.shown = true
+
+	return n, err
+}
+
+// callback is used as the terminal's AutoCompleteCallback.
+func (c *completion) callback(line string, pos int, key rune) (string, int, bool) {
+	if key != '\t' {
+		return "", 0, false
+	}
+
+	candidates := c.complete(line, pos)
+	if len(candidates) == 0 {
+		return "", 0, false
+	}
+
+	completed := commonPrefix(candidates)
+	if len(candidates) == 1 || len(completed) > pos {
+		line = completed + line[pos:]
+		pos = len(completed)
+	}
+
+	if len(candidates) > 1 {
+		c.list = c.render(candidates, line, pos)
+	}
+
+	return line, pos, true
+}
+
+// render draws the candidates below the line, returning the cursor to where
+// it was.
+func (c *completion) render(candidates []string, line string, pos int) string {
+	start := utf8.RuneCountInString(c.prompt)
+	cursor := start + utf8.RuneCountInString(line[:pos])
+	end := start + utf8.RuneCountInString(line)
+
+	rows := c.layout(candidates)
+
+	var buf strings.Builder
+
+	if down := end/c.width - cursor/c.width; down > 0 {
+		fmt.Fprintf(&buf, "\x1b[%dB", down)
+	}
+
+	buf.WriteString("\r\n\x1b[J")
+	buf.WriteString(strings.Join(rows, "\r\n"))
+
+	fmt.Fprintf(&buf, "\x1b[%dA\r", end/c.width-cursor/c.width+len(rows))
+
+	if col := cursor % c.width; col > 0 {
+		fmt.Fprintf(&buf, "\x1b[%dC", col)
+	}
+
+	return buf.String()
+}
+
+// layout arranges the candidates in columns, using at most half of the
+// terminal's height.
+func (c *completion) layout(candidates []string) []string {
+	widest := 0
+	for _, candidate := range candidates {
+		if width := utf8.RuneCountInString(candidate); width > widest {
+			widest = width
+		}
+	}
+
+	colWidth := widest + 2
+	if colWidth > c.width {
+		colWidth = c.width
+	}
+
+	cols := c.width / colWidth
+
+	maxRows := c.height / 2
+	if maxRows < 1 {
+		maxRows = 1
+	}
+
+	var rows []string
+	for i := 0; i < len(candidates); i += cols {
+		if len(rows) == maxRows-1 && i+cols < len(candidates) {
+			rows = append(rows, fmt.Sprintf("(%d more)", len(candidates)-i))
+			break
+		}
+
+		var row strings.Builder
+		for j := i; j < i+cols && j < len(candidates); j++ {
+			candidate := truncate(candidates[j], colWidth-1)
+
+			row.WriteString(candidate)
+
+			if j+1 < i+cols && j+1 < len(candidates) {
+				row.WriteString(strings.Repeat(" ", colWidth-utf8.RuneCountInString(candidate)))
+			}
+		}
+
+		rows = append(rows, row.String())
+	}
+
+	return rows
+}
+
+// clear removes the list, if any, once the line has been entered, leaving
+// the cursor at the start of the line below it.
+func (c *completion) clear() error {
+	c.list = ""
+
+	if !c.shown {
+		return nil
+	}
+
+	c.shown = false
+
+	_, err := io.WriteString(c.writer, "\x1b[J")
+	return err
+}
+
+// commonPrefix returns the longest prefix shared by all of the strings.
+func commonPrefix(strs []string) string {
+	prefix := strs[0]
+	for _, str := range strs[1:] {
+		for !strings.HasPrefix(str, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+
+	for !utf8.ValidString(prefix) {
+		prefix = prefix[:len(prefix)-1]
+	}
+
+	return prefix
+}
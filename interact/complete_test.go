@@ -0,0 +1,136 @@
+package interact_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vito/go-interact/interact"
+	"github.com/vito/go-interact/interact/interacttest"
+)
+
+var _ = Describe("Completing input", func() {
+	Describe("CompleteWords", func() {
+		It("offers the words starting with the text before the cursor", func() {
+			complete := interact.CompleteWords("main", "master", "develop")
+			Expect(complete("ma", 2)).To(Equal([]string{"main", "master"}))
+			Expect(complete("dev", 3)).To(Equal([]string{"develop"}))
+			Expect(complete("mas-ignored", 3)).To(Equal([]string{"master"}))
+			Expect(complete("x", 1)).To(BeEmpty())
+		})
+	})
+
+	Describe("CompleteChoices", func() {
+		It("offers the choices' displays", func() {
+			complete := interact.CompleteChoices(
+				interact.Choice{Display: "staging", Value: 1},
+				interact.Choice{Display: "production", Value: 2},
+			)
+			Expect(complete("st", 2)).To(Equal([]string{"staging"}))
+		})
+	})
+
+	Describe("CompletePaths", func() {
+		var dir string
+
+		BeforeEach(func() {
+			dir = GinkgoT().TempDir()
+
+			Expect(os.WriteFile(filepath.Join(dir, "notes.txt"), nil, 0644)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(dir, "notice.md"), nil, 0644)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(dir, ".hidden"), nil, 0644)).To(Succeed())
+			Expect(os.Mkdir(filepath.Join(dir, "nested"), 0755)).To(Succeed())
+		})
+
+		It("offers matching files, and directories with a trailing separator", func() {
+			line := filepath.Join(dir, "no")
+			Expect(interact.CompletePaths(line, len(line))).To(Equal([]string{
+				filepath.Join(dir, "notes.txt"),
+				filepath.Join(dir, "notice.md"),
+			}))
+
+			line = filepath.Join(dir, "n")
+			Expect(interact.CompletePaths(line, len(line))).To(ContainElement(filepath.Join(dir, "nested") + string(filepath.Separator)))
+		})
+
+		It("only offers hidden files once a dot is typed", func() {
+			line := dir + string(filepath.Separator)
+			Expect(interact.CompletePaths(line, len(line))).ToNot(ContainElement(filepath.Join(dir, ".hidden")))
+
+			line = dir + string(filepath.Separator) + "."
+			Expect(interact.CompletePaths(line, len(line))).To(Equal([]string{filepath.Join(dir, ".hidden")}))
+		})
+
+		It("offers nothing for a directory that does not exist", func() {
+			line := filepath.Join(dir, "bogus", "x")
+			Expect(interact.CompletePaths(line, len(line))).To(BeEmpty())
+		})
+	})
+
+	Context("on a terminal", func() {
+		var (
+			term   *interacttest.Terminal
+			branch string
+		)
+
+		BeforeEach(func() {
+			term = interacttest.NewTerminal(GinkgoT())
+
+			interaction := term.Interaction("Branch")
+			interaction.Complete = interact.CompleteWords("main", "master", "feature/login", "feature/logout")
+
+			branch = ""
+			term.Start(func() error {
+				return interaction.Resolve(interact.Required(&branch))
+			})
+
+			term.ExpectCursorLine("Branch:")
+		})
+
+		It("completes the only candidate when Tab is pressed", func() {
+			term.Type("mai")
+			term.Press(interacttest.KeyTab)
+			term.ExpectCursorLine("Branch: main")
+
+			term.Press(interacttest.KeyEnter)
+			Expect(term.Wait()).To(Succeed())
+			Expect(branch).To(Equal("main"))
+		})
+
+		It("completes as far as the candidates agree, and lists them below the prompt", func() {
+			term.Type("fe")
+			term.Press(interacttest.KeyTab)
+			term.ExpectCursorLine("Branch: feature/log")
+			term.ExpectScreen("feature/login   feature/logout")
+
+			Expect(term.Screen().Lines()).To(Equal([]string{
+				"Branch: feature/log",
+				"feature/login   feature/logout",
+			}))
+
+			term.Type("out")
+			term.ExpectCursorLine("Branch: feature/logout")
+
+			term.Press(interacttest.KeyEnter)
+			Expect(term.Wait()).To(Succeed())
+			Expect(branch).To(Equal("feature/logout"))
+
+			Eventually(term.Screen().Lines).Should(Equal([]string{
+				"Branch: feature/logout",
+			}))
+		})
+
+		It("leaves the line alone when there is nothing to complete", func() {
+			term.Type("x")
+			term.Press(interacttest.KeyTab)
+			term.Type("y")
+			term.ExpectCursorLine("Branch: xy")
+
+			term.Press(interacttest.KeyEnter)
+			Expect(term.Wait()).To(Succeed())
+			Expect(branch).To(Equal("xy"))
+		})
+	})
+})
@@ -0,0 +1,181 @@
+package interact_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/kr/pty"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"golang.org/x/term"
+
+	"github.com/vito/go-interact/interact"
+	"github.com/vito/go-interact/interact/interacttest"
+)
+
+var _ = Describe("Resolving with a context", func() {
+	var (
+		input  *io.PipeReader
+		output *gbytes.Buffer
+
+		interaction interact.Interaction
+	)
+
+	BeforeEach(func() {
+		var inputWriter *io.PipeWriter
+		input, inputWriter = io.Pipe()
+		output = gbytes.NewBuffer()
+
+		DeferCleanup(inputWriter.Close)
+
+		interaction = interact.NewInteraction("some prompt")
+		interaction.Input = input
+		interaction.Output = output
+	})
+
+	Context("when the context is already canceled", func() {
+		It("returns the context's error without prompting", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			thing := "some default"
+			err := interaction.ResolveContext(ctx, &thing)
+			Expect(err).To(Equal(context.Canceled))
+			Expect(thing).To(Equal("some default"))
+		})
+	})
+
+	Context("when the context times out while waiting for input", func() {
+		It("returns the context's error and leaves the destination alone", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+
+			thing := "some default"
+			err := interaction.ResolveContext(ctx, &thing)
+			Expect(err).To(Equal(context.DeadlineExceeded))
+			Expect(thing).To(Equal("some default"))
+
+			Expect(output).To(gbytes.Say(`some prompt \(some default\): \n`))
+		})
+	})
+
+	It("leaves what is entered afterwards to the next interaction", func() {
+		var inputWriter *io.PipeWriter
+		input, inputWriter = io.Pipe()
+		interaction.Input = input
+
+		DeferCleanup(inputWriter.Close)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		var first string
+		Expect(interaction.ResolveContext(ctx, &first)).To(Equal(context.DeadlineExceeded))
+
+		var second string
+		errs := make(chan error, 1)
+		go func() {
+			errs <- interaction.Resolve(&second)
+		}()
+
+		Eventually(output).Should(gbytes.Say(`some prompt \(\): \n`))
+		Eventually(output).Should(gbytes.Say(`some prompt \(\): `))
+
+		_, err := inputWriter.Write([]byte("alice\n"))
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(errs).Should(Receive(BeNil()))
+		Expect(first).To(BeEmpty())
+		Expect(second).To(Equal("alice"))
+	})
+
+	Context("when the input is a terminal", func() {
+		It("restores the terminal state when the context is canceled", func() {
+			aPty, tty, err := pty.Open()
+			Expect(err).NotTo(HaveOccurred())
+
+			defer aPty.Close()
+			defer tty.Close()
+
+			go io.Copy(io.Discard, aPty)
+
+			before, err := term.GetState(int(tty.Fd()))
+			Expect(err).NotTo(HaveOccurred())
+
+			interaction.Input = tty
+			interaction.Output = tty
+
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+
+			var thing string
+			err = interaction.ResolveContext(ctx, &thing)
+			Expect(err).To(Equal(context.DeadlineExceeded))
+
+			after, err := term.GetState(int(tty.Fd()))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(after).To(Equal(before))
+		})
+
+		It("stops reading from the terminal when the context is canceled", func() {
+			aPty, tty, err := pty.Open()
+			Expect(err).NotTo(HaveOccurred())
+
+			defer aPty.Close()
+			defer tty.Close()
+
+			go io.Copy(io.Discard, aPty)
+
+			interaction.Input = tty
+			interaction.Output = tty
+
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+
+			var thing string
+			Expect(interaction.ResolveContext(ctx, &thing)).To(Equal(context.DeadlineExceeded))
+
+			_, err = aPty.Write([]byte("hello\n"))
+			Expect(err).NotTo(HaveOccurred())
+
+			read := make(chan string, 1)
+			go func() {
+				buf := make([]byte, 64)
+				n, _ := tty.Read(buf)
+				read <- string(buf[:n])
+			}()
+
+			Eventually(read).Should(Receive(Equal("hello\n")))
+		})
+
+		It("leaves what is typed afterwards to the next interaction", func() {
+			term := interacttest.NewTerminal(GinkgoT())
+
+			var first, second string
+			term.Start(func() error {
+				ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+				defer cancel()
+
+				err := term.Interaction("First").ResolveContext(ctx, &first)
+				if err != context.DeadlineExceeded {
+					return fmt.Errorf("expected the first interaction to time out, got %v", err)
+				}
+
+				return term.Interaction("Second").Resolve(&second)
+			})
+
+			term.ExpectCursorLine("Second ():")
+			term.Type("alice")
+			term.Press(interacttest.KeyEnter)
+
+			Expect(term.Wait()).To(Succeed())
+			Expect(first).To(BeEmpty())
+			Expect(second).To(Equal("alice"))
+
+			Eventually(term.Screen().Lines).Should(HaveExactElements("First ():", "Second (): alice"))
+		})
+	})
+})
@@ -0,0 +1,100 @@
+package interact_test
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+
+	"github.com/vito/go-interact/interact"
+	"github.com/vito/go-interact/interact/interacttest"
+)
+
+var _ = Describe("Editing defaults in place", func() {
+	Context("on a terminal", func() {
+		var term *interacttest.Terminal
+
+		BeforeEach(func() {
+			term = interacttest.NewTerminal(GinkgoT())
+		})
+
+		resolve := func(prompt string, dst interface{}) {
+			interaction := term.Interaction(prompt)
+			interaction.EditDefault = true
+
+			term.Start(func() error {
+				return interaction.Resolve(dst)
+			})
+		}
+
+		It("starts the line out with the default, for the user to edit", func() {
+			url := "https://example.com/pth"
+			resolve("URL", &url)
+
+			term.ExpectCursorLine("URL: https://example.com/pth")
+
+			term.Press(interacttest.KeyLeft, interacttest.KeyLeft)
+			term.Type("a")
+			term.ExpectCursorLine("URL: https://example.com/path")
+
+			term.Press(interacttest.KeyEnter)
+			Expect(term.Wait()).To(Succeed())
+			Expect(url).To(Equal("https://example.com/path"))
+		})
+
+		It("keeps the default when it is entered as-is", func() {
+			url := "https://example.com"
+			resolve("URL", &url)
+
+			term.ExpectCursorLine("URL: https://example.com")
+			term.Press(interacttest.KeyEnter)
+
+			Expect(term.Wait()).To(Succeed())
+			Expect(url).To(Equal("https://example.com"))
+		})
+
+		It("starts over from the rejected line, so it can be fixed", func() {
+			port := 8080
+			resolve("Port", &port)
+
+			term.ExpectCursorLine("Port: 8080")
+			term.Type("x")
+			term.Press(interacttest.KeyEnter)
+
+			term.ExpectScreen("invalid input (not a number)")
+			term.ExpectCursorLine("Port: 8080x")
+
+			term.Press(interacttest.KeyBackspace, interacttest.KeyBackspace)
+			term.Type("1")
+			term.Press(interacttest.KeyEnter)
+
+			Expect(term.Wait()).To(Succeed())
+			Expect(port).To(Equal(8081))
+		})
+
+		It("asks for bools as usual", func() {
+			confirm := true
+			resolve("Continue?", &confirm)
+
+			term.ExpectCursorLine("Continue? [Yn]:")
+			term.Press(interacttest.KeyEnter)
+
+			Expect(term.Wait()).To(Succeed())
+			Expect(confirm).To(BeTrue())
+		})
+	})
+
+	It("shows the default in the prompt when the input is not a terminal", func() {
+		output := gbytes.NewBuffer()
+
+		url, err := interact.Ask("URL", "https://example.com",
+			interact.WithEditDefault(),
+			interact.WithInput(bytes.NewBufferString("\n")),
+			interact.WithOutput(output))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(url).To(Equal("https://example.com"))
+
+		Expect(string(output.Contents())).To(Equal("URL (https://example.com): \n"))
+	})
+})
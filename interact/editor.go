@@ -0,0 +1,177 @@
+package interact
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// Editor opens the user's editor to enter Multiline answers on a terminal, as
+// git does for commit messages.
+//
+// The editor is given a temporary file containing the current value, which
+// is replaced by the file's contents once the editor exits. Lines starting
+// with "#" are removed, along with any blank lines at the start or end.
+type Editor struct {
+	// Command is the editor to run, with any arguments. If empty, $VISUAL or
+	// $EDITOR is used. If none of them are set, the answer is entered as
+	// usual.
+	//
+	// As with git, the command is run by the shell, with the file appended
+	// as its last argument, so it may be quoted, e.g. if its path contains
+	// spaces.
+	Command string
+
+	// Instructions, if set, are written below the current value as comment
+	// lines.
+	Instructions string
+}
+
+// textEditor is implemented by users that can enter text in an editor.
+type textEditor interface {
+	Edit(prompt string, editor Editor, text string) (string, error)
+}
+
+// command returns the editor to run, with any arguments.
+func (editor Editor) command() string {
+	command := editor.Command
+	if command == "" {
+		command = os.Getenv("VISUAL")
+	}
+
+	if command == "" {
+		command = os.Getenv("EDITOR")
+	}
+
+	return strings.TrimSpace(command)
+}
+
+// cmd returns the command running the editor on the given file.
+func (editor Editor) cmd(path string) *exec.Cmd {
+	command := editor.command()
+
+	if runtime.GOOS == "windows" {
+		// there is no shell to interpret the command
+		fields := strings.Fields(command)
+		return exec.Command(fields[0], append(fields[1:], path)...)
+	}
+
+	// pass the file as an argument to the shell rather than as part of the
+	// command, so that it never needs quoting
+	return exec.Command("sh", "-c", command+` "$@"`, command, path)
+}
+
+// file returns the contents of the file to edit.
+func (editor Editor) file(text string) string {
+	var file strings.Builder
+
+	if text != "" {
+		file.WriteString(text)
+		file.WriteString("\n")
+	}
+
+	if editor.Instructions != "" {
+		file.WriteString("\n")
+
+		for _, line := range strings.Split(editor.Instructions, "\n") {
+			file.WriteString(strings.TrimRight("# "+line, " "))
+			file.WriteString("\n")
+		}
+	}
+
+	return file.String()
+}
+
+// parse returns the answer from the contents of the edited file.
+func (editor Editor) parse(file string) string {
+	file = strings.ReplaceAll(file, "\r\n", "\n")
+
+	var lines []string
+	for _, line := range strings.Split(file, "\n") {
+		if !strings.HasPrefix(line, "#") {
+			lines = append(lines, line)
+		}
+	}
+
+	return strings.Trim(strings.Join(lines, "\n"), "\n")
+}
+
+// readLines reads an answer spanning several lines, in the user's editor if
+// the interaction has an Editor and the user is on a terminal.
+func (interaction Interaction) readLines(text string, user userIO, prompt string) (string, error) {
+	if interaction.Editor == nil || interaction.Editor.command() == "" {
+		return user.ReadLines(prompt)
+	}
+
+	editor, ok := user.(textEditor)
+	if !ok {
+		return user.ReadLines(prompt)
+	}
+
+	return editor.Edit(prompt, *interaction.Editor, text)
+}
+
+// Edit runs the editor on a temporary file containing the text, taking the
+// terminal out of raw mode while it runs, and returns the answer it was
+// edited into.
+func (u ttyUser) Edit(prompt string, editor Editor, text string) (answer string, err error) {
+	_, err = fmt.Fprintf(u.Terminal, "%s%s(waiting for editor)%s\r\n", prompt, startFaint, resetStyle)
+	if err != nil {
+		return "", err
+	}
+
+	file, err := os.CreateTemp("", "interact-*.txt")
+	if err != nil {
+		return "", err
+	}
+
+	defer os.Remove(file.Name())
+
+	_, err = file.WriteString(editor.file(text))
+	if err != nil {
+		file.Close()
+		return "", err
+	}
+
+	err = file.Close()
+	if err != nil {
+		return "", err
+	}
+
+	if u.state != nil {
+		err = term.Restore(int(u.file.Fd()), u.state)
+		if err != nil {
+			return "", err
+		}
+
+		defer func() {
+			// the state before entering raw mode again is not needed, as the
+			// original state is restored once the interaction is resolved
+			_, rawErr := term.MakeRaw(int(u.file.Fd()))
+			if rawErr != nil && err == nil {
+				answer, err = "", rawErr
+			}
+		}()
+	}
+
+	cmd := editor.cmd(file.Name())
+	cmd.Stdin = u.file
+	cmd.Stdout = u.output
+	cmd.Stderr = u.output
+
+	err = cmd.Run()
+	if err != nil {
+		return "", fmt.Errorf("editor failed: %w", err)
+	}
+
+	edited, err := os.ReadFile(file.Name())
+	if err != nil {
+		return "", err
+	}
+
+	return editor.parse(string(edited)), nil
+}
@@ -0,0 +1,165 @@
+package interact_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+
+	"github.com/vito/go-interact/interact"
+	"github.com/vito/go-interact/interact/interacttest"
+)
+
+var _ = Describe("Entering answers in an editor", func() {
+	var (
+		dir    string
+		editor string
+	)
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+
+		// the editor keeps a copy of the file it was given and the terminal's
+		// settings, and replaces the file with an answer
+		editor = filepath.Join(dir, "editor")
+		Expect(os.WriteFile(editor, []byte(`#!/bin/sh
+cp "$1" "$(dirname "$0")/given"
+stty -a > "$(dirname "$0")/stty"
+printf '\n# a comment\nrelease notes\n\nsecond paragraph\n\n# more comments\n' > "$1"
+`), 0755)).To(Succeed())
+
+		GinkgoT().Setenv("VISUAL", "")
+		GinkgoT().Setenv("EDITOR", "")
+	})
+
+	given := func() string {
+		contents, err := os.ReadFile(filepath.Join(dir, "given"))
+		Expect(err).ToNot(HaveOccurred())
+		return string(contents)
+	}
+
+	Context("on a terminal", func() {
+		var term *interacttest.Terminal
+
+		BeforeEach(func() {
+			term = interacttest.NewTerminal(GinkgoT())
+		})
+
+		resolve := func(interaction interact.Interaction, dst interface{}) {
+			term.Start(func() error {
+				return interaction.Resolve(dst)
+			})
+		}
+
+		It("edits the current value and instructions, removing comments from the answer", func() {
+			interaction := term.Interaction("Release notes")
+			interaction.Editor = &interact.Editor{
+				Command:      editor,
+				Instructions: "Describe the release.\n\nLines starting with # are ignored.",
+			}
+
+			notes := interact.Multiline("draft")
+			resolve(interaction, &notes)
+
+			Expect(term.Wait()).To(Succeed())
+			Expect(notes).To(Equal(interact.Multiline("release notes\n\nsecond paragraph")))
+
+			Expect(given()).To(Equal("draft\n\n# Describe the release.\n#\n# Lines starting with # are ignored.\n"))
+
+			term.ExpectScreen("Release notes (draft): (waiting for editor)")
+		})
+
+		It("runs the editor with the terminal out of raw mode", func() {
+			interaction := term.Interaction("Release notes")
+			interaction.Editor = &interact.Editor{Command: editor}
+
+			var notes interact.Multiline
+			resolve(interaction, &notes)
+			Expect(term.Wait()).To(Succeed())
+
+			stty, err := os.ReadFile(filepath.Join(dir, "stty"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(stty)).To(MatchRegexp(`(^|\s)icanon(\s|$)`))
+			Expect(string(stty)).To(MatchRegexp(`(^|\s)echo(\s|$)`))
+		})
+
+		It("runs the editor with the shell, so that it can be quoted and given arguments", func() {
+			spaced := filepath.Join(dir, "my editor")
+			Expect(os.Mkdir(spaced, 0755)).To(Succeed())
+
+			// the editor keeps its arguments, and answers in the file it was
+			// given last
+			Expect(os.WriteFile(filepath.Join(spaced, "editor"), []byte(`#!/bin/sh
+for arg; do echo "$arg"; done > "$(dirname "$0")/args"
+for file; do :; done
+echo "edited" > "$file"
+`), 0755)).To(Succeed())
+
+			interaction := term.Interaction("Release notes")
+			interaction.Editor = &interact.Editor{
+				Command: `"` + filepath.Join(spaced, "editor") + `" --title 'Release notes'`,
+			}
+
+			var notes interact.Multiline
+			resolve(interaction, &notes)
+			Expect(term.Wait()).To(Succeed())
+			Expect(notes).To(Equal(interact.Multiline("edited")))
+
+			args, err := os.ReadFile(filepath.Join(spaced, "args"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(args)).To(MatchRegexp(`^--title\nRelease notes\n.+\.txt\n$`))
+		})
+
+		It("uses $VISUAL, then $EDITOR", func() {
+			GinkgoT().Setenv("EDITOR", editor)
+
+			interaction := term.Interaction("Release notes")
+			interaction.Editor = &interact.Editor{}
+
+			var notes interact.Multiline
+			resolve(interaction, &notes)
+			Expect(term.Wait()).To(Succeed())
+			Expect(notes).To(Equal(interact.Multiline("release notes\n\nsecond paragraph")))
+		})
+
+		It("reads lines as usual when there is no editor", func() {
+			interaction := term.Interaction("Release notes")
+			interaction.Editor = &interact.Editor{}
+
+			var notes interact.Multiline
+			resolve(interaction, &notes)
+
+			term.ExpectScreen("Release notes (): (Ctrl-D to finish)")
+			term.Type("typed notes")
+			term.Press(interacttest.KeyCtrlD)
+
+			Expect(term.Wait()).To(Succeed())
+			Expect(notes).To(Equal(interact.Multiline("typed notes")))
+		})
+
+		It("returns an error if the editor fails", func() {
+			interaction := term.Interaction("Release notes")
+			interaction.Editor = &interact.Editor{Command: "false"}
+
+			var notes interact.Multiline
+			resolve(interaction, &notes)
+			Expect(term.Wait()).To(MatchError(HavePrefix("editor failed: ")))
+		})
+	})
+
+	It("reads lines as usual when the input is not a terminal", func() {
+		interaction := interact.NewInteraction("Release notes")
+		interaction.Input = bytes.NewBufferString("typed notes\n.\n")
+		interaction.Output = gbytes.NewBuffer()
+		interaction.Editor = &interact.Editor{Command: editor}
+
+		var notes interact.Multiline
+		Expect(interaction.Resolve(&notes)).To(Succeed())
+		Expect(notes).To(Equal(interact.Multiline("typed notes")))
+
+		Expect(filepath.Join(dir, "given")).ToNot(BeAnExistingFile())
+	})
+})
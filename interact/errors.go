@@ -7,12 +7,19 @@ import (
 )
 
 // ErrNotANumber is used internally by Resolve when the user enters a bogus
-// value when resolving into an int.
+// value when resolving into a number.
 //
 // Resolve will retry on this error; it is only exposed so you can know where
 // the string is coming from.
 var ErrNotANumber = errors.New("not a number")
 
+// ErrOutOfRange is used internally by Resolve when the user enters a number
+// that does not fit in the destination's type, e.g. 256 for a uint8.
+//
+// Resolve will retry on this error; it is only exposed so you can know where
+// the string is coming from.
+var ErrOutOfRange = errors.New("out of range")
+
 // ErrNotBoolean is used internally by Resolve when the user enters a bogus
 // value when resolving into a bool.
 //
@@ -20,6 +27,10 @@ var ErrNotANumber = errors.New("not a number")
 // the string is coming from.
 var ErrNotBoolean = errors.New("not y, n, yes, or no")
 
+// errBack is returned when the user asks to go back to the previous step of
+// a Flow.
+var errBack = errors.New("go back")
+
 // NotAssignableError is returned by Resolve when the value present in the
 // Choice the user selected is not assignable to the destination value during
 // Resolve.
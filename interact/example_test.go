@@ -22,8 +22,10 @@ var _ = BeforeEach(func() {
 })
 
 type Example struct {
-	Prompt  string
-	Choices []interact.Choice
+	Prompt     string
+	Choices    []interact.Choice
+	TimeLayout string
+	Validate   func(interface{}) error
 
 	Input string
 
@@ -38,6 +40,8 @@ func (example Example) Run() {
 	output := gbytes.NewBuffer()
 
 	interaction := interact.NewInteraction(example.Prompt, choices...)
+	interaction.TimeLayout = example.TimeLayout
+	interaction.Validate = example.Validate
 	interaction.Input = input
 	interaction.Output = output
 
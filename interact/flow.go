@@ -0,0 +1,333 @@
+package interact
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+)
+
+// Step is a single question asked as part of a Flow, resolving its
+// Interaction into its Destination.
+type Step struct {
+	Interaction
+
+	Destination interface{}
+
+	// When, if set, is called before the step is asked. If it returns false,
+	// the step is skipped, leaving its destination untouched.
+	When func() bool
+
+	// Prepare, if set, is called before the step is asked, allowing its
+	// prompt, choices, or destination to be computed from earlier answers.
+	// The default may be computed by setting the value held in the
+	// destination.
+	Prepare func(*Step)
+}
+
+// NewStep constructs a step resolving into the given destination, with the
+// given prompt, limited to the given choices, if any.
+func NewStep(prompt string, dst interface{}, choices ...Choice) Step {
+	return Step{
+		Interaction: NewInteraction(prompt, choices...),
+		Destination: dst,
+	}
+}
+
+// Flow is a series of questions, where later questions may depend on the
+// answers to earlier ones.
+//
+// For example, to only ask for a certificate if TLS is enabled:
+//
+//	var enableTLS bool
+//	var certPath string
+//
+//	flow := interact.NewFlow(
+//		interact.NewStep("Enable TLS?", &enableTLS),
+//		interact.Step{
+//			Interaction: interact.NewInteraction("Certificate path"),
+//			Destination: interact.Required(&certPath),
+//			When:        func() bool { return enableTLS },
+//		},
+//	)
+//
+//	err := flow.Resolve()
+type Flow struct {
+	Steps []Step
+
+	// Input and Output, if set, are used for every step, in place of the
+	// steps' own.
+	Input  io.Reader
+	Output io.Writer
+
+	// BackInput, if set, is the input the user can enter to go back to the
+	// previous question and change their answer, e.g. "<". On terminals,
+	// pressing escape in a menu also goes back.
+	BackInput string
+
+	// Answers, if set, is used for every step, in place of the steps' own.
+	Answers Answers
+
+	// NonInteractive, if not NonInteractivePrompt, is used for every step, in
+	// place of the steps' own.
+	NonInteractive NonInteractivePolicy
+
+	// Recorder and Replayer, if set, are used for every step, in place of the
+	// steps' own.
+	Recorder *Recorder
+	Replayer *Replayer
+
+	// History, if set, is used for every step, in place of the steps' own.
+	History *History
+
+	// Review, if true, lists every question and its answer once all of them
+	// have been answered, and asks the user to confirm them. If the user
+	// says they are not correct, the questions are asked again, with their
+	// answers as defaults.
+	Review bool
+}
+
+// NewFlow constructs a flow asking the given steps in order.
+//
+// Defaults Input and Output to os.Stdin and os.Stdout, respectively.
+func NewFlow(steps ...Step) Flow {
+	return Flow{
+		Steps:  steps,
+		Input:  os.Stdin,
+		Output: os.Stdout,
+	}
+}
+
+// Resolve asks each step in order, skipping any whose When returns false.
+// It stops at the first error.
+//
+// If BackInput is entered, the previous step that was asked is asked again,
+// along with every step after it. Steps asked again, whether going back or
+// after Review, keep their answers as defaults, even if their destination is
+// a RequiredDestination.
+func (flow Flow) Resolve() error {
+	return flow.ResolveContext(context.Background())
+}
+
+// ResolveContext is like Resolve, but gives up on waiting for the user once
+// ctx is done, returning ctx.Err().
+func (flow Flow) ResolveContext(ctx context.Context) error {
+	// the steps asked so far, so the user can go back through them
+	var asked []askedStep
+
+	// the steps that have been answered, which are no longer required when
+	// asked again, so that their answers are kept by default
+	answered := map[int]bool{}
+
+	back := func(i int) int {
+		for j := len(asked) - 1; j >= 0; j-- {
+			if !asked[j].asked {
+				// resolved without asking, e.g. from Answers or a Source;
+				// there's nothing to change
+				continue
+			}
+
+			prev := asked[j]
+			asked = asked[:j]
+
+			return prev.index
+		}
+
+		// nothing to go back to; ask the same step again
+		return i
+	}
+
+	i := 0
+	for {
+		if i == len(flow.Steps) {
+			if !flow.Review {
+				return nil
+			}
+
+			correct, err := flow.review(ctx, asked)
+			if errors.Is(err, errBack) {
+				i = back(i)
+				continue
+			}
+
+			if err != nil {
+				return err
+			}
+
+			if correct {
+				return nil
+			}
+
+			i, asked = 0, nil
+			continue
+		}
+
+		step, ask := flow.prepare(flow.Steps[i])
+		if !ask {
+			i++
+			continue
+		}
+
+		dst := step.Destination
+		if required, ok := dst.(RequiredDestination); ok && answered[i] {
+			dst = required.Destination
+		}
+
+		stepAsked, err := step.Interaction.resolveContext(ctx, dst)
+		if errors.Is(err, errBack) {
+			i = back(i)
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+
+		answered[i] = true
+
+		asked = append(asked, askedStep{index: i, step: step, asked: stepAsked})
+		i++
+	}
+}
+
+type askedStep struct {
+	index int
+	step  Step
+
+	// asked is false if the step was resolved without asking the user
+	asked bool
+}
+
+// review lists each question asked along with its answer, and asks the user
+// to confirm them.
+func (flow Flow) review(ctx context.Context, asked []askedStep) (bool, error) {
+	confirm := NewInteraction("Are these answers correct?")
+	confirm.back = flow.BackInput
+
+	if len(asked) > 0 {
+		// steps carry the flow's streams and policy, if it has any
+		last := asked[len(asked)-1].step
+		confirm.Input = last.Interaction.Input
+		confirm.Output = last.Interaction.Output
+		confirm.NonInteractive = last.Interaction.NonInteractive
+		confirm.Recorder = last.Interaction.Recorder
+		confirm.Replayer = last.Interaction.Replayer
+	} else {
+		confirm.NonInteractive = flow.NonInteractive
+		confirm.Recorder = flow.Recorder
+		confirm.Replayer = flow.Replayer
+
+		if flow.Input != nil {
+			confirm.Input = flow.Input
+		}
+
+		if flow.Output != nil {
+			confirm.Output = flow.Output
+		}
+	}
+
+	for _, a := range asked {
+		_, err := fmt.Fprintf(confirm.Output, "%s: %s\n", a.step.Interaction.Prompt, a.step.Interaction.formatAnswer(a.step.Destination))
+		if err != nil {
+			return false, err
+		}
+	}
+
+	correct := true
+	err := confirm.ResolveContext(ctx, &correct)
+	if err != nil {
+		return false, err
+	}
+
+	return correct, nil
+}
+
+// prepare returns the step as it should be asked given the answers so far,
+// or false if it should be skipped.
+func (flow Flow) prepare(step Step) (Step, bool) {
+	if step.When != nil && !step.When() {
+		return step, false
+	}
+
+	if flow.Input != nil {
+		step.Interaction.Input = flow.Input
+	}
+
+	if flow.Output != nil {
+		step.Interaction.Output = flow.Output
+	}
+
+	if flow.Answers != nil {
+		step.Interaction.Answers = flow.Answers
+	}
+
+	if flow.NonInteractive != NonInteractivePrompt {
+		step.Interaction.NonInteractive = flow.NonInteractive
+	}
+
+	if flow.Recorder != nil {
+		step.Interaction.Recorder = flow.Recorder
+	}
+
+	if flow.Replayer != nil {
+		step.Interaction.Replayer = flow.Replayer
+	}
+
+	if flow.History != nil {
+		step.Interaction.History = flow.History
+	}
+
+	step.Interaction.back = flow.BackInput
+
+	// don't let Prepare modify the original step's choices
+	step.Interaction.Choices = append([]Choice(nil), step.Interaction.Choices...)
+
+	if step.Prepare != nil {
+		step.Prepare(&step)
+	}
+
+	return step, true
+}
+
+// formatAnswer returns the answer held in dst for showing to the user, using the
+// display of the chosen choices and masking passwords.
+func (interaction Interaction) formatAnswer(dst interface{}) string {
+	if required, ok := dst.(RequiredDestination); ok {
+		dst = required.Destination
+	}
+
+	if interaction.isMultipleChoice(dst) {
+		nums, _ := interaction.choiceNumbers(dst)
+
+		displays := make([]string, len(nums))
+		for i, num := range nums {
+			displays[i] = interaction.Choices[num-1].Display
+		}
+
+		return strings.Join(displays, ", ")
+	}
+
+	if len(interaction.Choices) > 0 {
+		if num, present := interaction.choiceNumber(dst); present {
+			return interaction.Choices[num-1].Display
+		}
+	}
+
+	if pass, ok := dst.(*Password); ok {
+		if len(*pass) == 0 {
+			return ""
+		}
+
+		// don't reveal the length, either
+		return "********"
+	}
+
+	if answer, ok := interaction.format(dst); ok {
+		return answer
+	}
+
+	return fmt.Sprint(reflect.ValueOf(dst).Elem().Interface())
+}
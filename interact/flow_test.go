@@ -0,0 +1,318 @@
+package interact_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+
+	"github.com/vito/go-interact/interact"
+)
+
+var _ = Describe("Flows", func() {
+	var (
+		input  *bytes.Buffer
+		output *gbytes.Buffer
+
+		enableTLS bool
+		certPath  string
+		port      int
+
+		flow interact.Flow
+	)
+
+	BeforeEach(func() {
+		input = new(bytes.Buffer)
+		output = gbytes.NewBuffer()
+
+		enableTLS = false
+		certPath = ""
+		port = 0
+
+		flow = interact.NewFlow(
+			interact.NewStep("Enable TLS?", &enableTLS),
+			interact.Step{
+				Interaction: interact.NewInteraction("Certificate path"),
+				Destination: interact.Required(&certPath),
+				When:        func() bool { return enableTLS },
+			},
+			interact.Step{
+				Interaction: interact.NewInteraction("Port"),
+				Destination: &port,
+				Prepare: func(step *interact.Step) {
+					if enableTLS {
+						port = 443
+					} else {
+						port = 80
+					}
+				},
+			},
+		)
+		flow.Input = input
+		flow.Output = output
+	})
+
+	It("skips steps whose condition is not met", func() {
+		input.WriteString("n\n\n")
+
+		Expect(flow.Resolve()).To(Succeed())
+		Expect(enableTLS).To(BeFalse())
+		Expect(certPath).To(BeEmpty())
+		Expect(port).To(Equal(80))
+
+		Expect(string(output.Contents())).To(Equal("Enable TLS? [yN]: n\nPort (80): \n"))
+	})
+
+	It("asks steps whose condition is met, preparing them from earlier answers", func() {
+		input.WriteString("y\n/etc/cert.pem\n\n")
+
+		Expect(flow.Resolve()).To(Succeed())
+		Expect(enableTLS).To(BeTrue())
+		Expect(certPath).To(Equal("/etc/cert.pem"))
+		Expect(port).To(Equal(443))
+
+		Expect(string(output.Contents())).To(Equal("Enable TLS? [yN]: y\nCertificate path: /etc/cert.pem\nPort (443): \n"))
+	})
+
+	It("allows steps to compute their prompt and choices", func() {
+		var environment, cluster string
+
+		clusters := map[string][]string{
+			"staging":    {"stage-1"},
+			"production": {"prod-1", "prod-2"},
+		}
+
+		flow.Steps = []interact.Step{
+			interact.NewStep("Environment", &environment,
+				interact.Choice{Display: "Staging", Value: "staging"},
+				interact.Choice{Display: "Production", Value: "production"},
+			),
+			{
+				Destination: &cluster,
+				Prepare: func(step *interact.Step) {
+					step.Prompt = fmt.Sprintf("Cluster in %s", environment)
+
+					for _, name := range clusters[environment] {
+						step.Choices = append(step.Choices, interact.Choice{Display: name, Value: name})
+					}
+				},
+			},
+		}
+
+		input.WriteString("2\n2\n")
+
+		Expect(flow.Resolve()).To(Succeed())
+		Expect(environment).To(Equal("production"))
+		Expect(cluster).To(Equal("prod-2"))
+
+		Expect(string(output.Contents())).To(Equal("1: Staging\n2: Production\nEnvironment: 2\n1: prod-1\n2: prod-2\nCluster in production: 2\n"))
+	})
+
+	It("stops at the first error", func() {
+		input.WriteString("y\n")
+
+		Expect(flow.Resolve()).To(Equal(io.EOF))
+		Expect(port).To(Equal(0))
+	})
+
+	Context("when going back is allowed", func() {
+		BeforeEach(func() {
+			flow.BackInput = "<"
+		})
+
+		It("asks the previous question again, with its answer as the default", func() {
+			input.WriteString("y\n<\nn\n\n")
+
+			Expect(flow.Resolve()).To(Succeed())
+			Expect(enableTLS).To(BeFalse())
+			Expect(certPath).To(BeEmpty())
+			Expect(port).To(Equal(80))
+
+			Expect(string(output.Contents())).To(Equal("Enable TLS? [yN]: y\nCertificate path: <\nEnable TLS? [Yn]: n\nPort (80): \n"))
+		})
+
+		It("skips steps whose condition is not met when going back", func() {
+			input.WriteString("n\n<\ny\n/etc/cert.pem\n\n")
+
+			Expect(flow.Resolve()).To(Succeed())
+			Expect(enableTLS).To(BeTrue())
+			Expect(certPath).To(Equal("/etc/cert.pem"))
+
+			Expect(string(output.Contents())).To(Equal("Enable TLS? [yN]: n\nPort (80): <\nEnable TLS? [yN]: y\nCertificate path: /etc/cert.pem\nPort (443): \n"))
+		})
+
+		It("skips steps resolved from answers when going back", func() {
+			flow.Steps[1].Name = "cert"
+			flow.Answers = interact.Answers{"cert": "/etc/cert.pem"}
+
+			input.WriteString("y\n<\ny\n\n")
+
+			Expect(flow.Resolve()).To(Succeed())
+			Expect(certPath).To(Equal("/etc/cert.pem"))
+
+			Expect(string(output.Contents())).To(Equal("Enable TLS? [yN]: y\nPort (443): <\nEnable TLS? [Yn]: y\nPort (443): \n"))
+		})
+
+		It("keeps the answer to a required step as the default when going back to it", func() {
+			input.WriteString("y\n/etc/cert.pem\n<\n\n\n")
+
+			Expect(flow.Resolve()).To(Succeed())
+			Expect(certPath).To(Equal("/etc/cert.pem"))
+
+			Expect(string(output.Contents())).To(Equal("Enable TLS? [yN]: y\nCertificate path: /etc/cert.pem\nPort (443): <\nCertificate path (/etc/cert.pem): \nPort (443): \n"))
+		})
+
+		It("skips steps resolved from sources when going back", func() {
+			GinkgoT().Setenv("INTERACT_TEST_CERT", "/etc/cert.pem")
+			flow.Steps[1].Sources = []interact.Source{interact.Env("INTERACT_TEST_CERT")}
+
+			input.WriteString("y\n<\ny\n\n")
+
+			Expect(flow.Resolve()).To(Succeed())
+			Expect(certPath).To(Equal("/etc/cert.pem"))
+
+			Expect(string(output.Contents())).To(Equal("Enable TLS? [yN]: y\nPort (443): <\nEnable TLS? [Yn]: y\nPort (443): \n"))
+		})
+
+		It("skips steps resolved from their defaults when going back", func() {
+			certPath = "/etc/default.pem"
+			flow.Steps[1].Destination = &certPath
+			flow.Steps[1].NonInteractive = interact.NonInteractiveDefault
+
+			input.WriteString("y\n<\ny\n\n")
+
+			Expect(flow.Resolve()).To(Succeed())
+			Expect(certPath).To(Equal("/etc/default.pem"))
+
+			Expect(string(output.Contents())).To(Equal("Enable TLS? [yN]: y\nPort (443): <\nEnable TLS? [Yn]: y\nPort (443): \n"))
+		})
+
+		It("asks the first question again when going back from it", func() {
+			input.WriteString("<\nn\n\n")
+
+			Expect(flow.Resolve()).To(Succeed())
+			Expect(string(output.Contents())).To(Equal("Enable TLS? [yN]: <\nEnable TLS? [yN]: n\nPort (80): \n"))
+		})
+	})
+
+	Context("when reviewing answers", func() {
+		var (
+			username string
+			password interact.Password
+			role     string
+		)
+
+		BeforeEach(func() {
+			username = "root"
+			password = ""
+			role = "user"
+
+			flow.Steps = []interact.Step{
+				interact.NewStep("Username", &username),
+				interact.NewStep("Password", &password),
+				interact.NewStep("Role", &role,
+					interact.Choice{Display: "Administrator", Value: "admin"},
+					interact.Choice{Display: "User", Value: "user"},
+				),
+			}
+			flow.Review = true
+			flow.BackInput = "<"
+		})
+
+		It("lists the answers, masking passwords, and asks for confirmation", func() {
+			input.WriteString("admin\nhunter2\n1\ny\n")
+
+			Expect(flow.Resolve()).To(Succeed())
+			Expect(username).To(Equal("admin"))
+			Expect(password).To(Equal(interact.Password("hunter2")))
+			Expect(role).To(Equal("admin"))
+
+			Expect(string(output.Contents())).To(Equal(
+				"Username (root): admin\n" +
+					"Password (): \n" +
+					"1: Administrator\n2: User\nRole (2): 1\n" +
+					"Username: admin\nPassword: ********\nRole: Administrator\n" +
+					"Are these answers correct? [Yn]: y\n",
+			))
+		})
+
+		It("asks every question again if the answers are not correct", func() {
+			input.WriteString("admin\nhunter2\n1\nn\nbob\n\n2\n\n")
+
+			Expect(flow.Resolve()).To(Succeed())
+			Expect(username).To(Equal("bob"))
+			Expect(password).To(Equal(interact.Password("hunter2")))
+			Expect(role).To(Equal("user"))
+
+			Expect(string(output.Contents())).To(HaveSuffix(
+				"Are these answers correct? [Yn]: n\n" +
+					"Username (admin): bob\n" +
+					"Password (has default): \n" +
+					"1: Administrator\n2: User\nRole (1): 2\n" +
+					"Username: bob\nPassword: ********\nRole: User\n" +
+					"Are these answers correct? [Yn]: \n",
+			))
+		})
+
+		It("keeps the answers to required questions as defaults when asking again", func() {
+			var host string
+			var port int
+
+			flow.Steps = []interact.Step{
+				interact.NewStep("Host", interact.Required(&host)),
+				interact.NewStep("Port", interact.Required(&port)),
+			}
+
+			input.WriteString("db.example.com\n5432\nn\n\n5433\ny\n")
+
+			Expect(flow.Resolve()).To(Succeed())
+			Expect(host).To(Equal("db.example.com"))
+			Expect(port).To(Equal(5433))
+
+			Expect(string(output.Contents())).To(Equal(
+				"Host: db.example.com\nPort: 5432\n" +
+					"Host: db.example.com\nPort: 5432\n" +
+					"Are these answers correct? [Yn]: n\n" +
+					"Host (db.example.com): \nPort (5432): 5433\n" +
+					"Host: db.example.com\nPort: 5433\n" +
+					"Are these answers correct? [Yn]: y\n",
+			))
+		})
+
+		It("goes back to the last question from the confirmation", func() {
+			input.WriteString("admin\nhunter2\n1\n<\n2\ny\n")
+
+			Expect(flow.Resolve()).To(Succeed())
+			Expect(role).To(Equal("user"))
+
+			Expect(string(output.Contents())).To(HaveSuffix(
+				"Are these answers correct? [Yn]: <\n" +
+					"1: Administrator\n2: User\nRole (1): 2\n" +
+					"Username: admin\nPassword: ********\nRole: User\n" +
+					"Are these answers correct? [Yn]: y\n",
+			))
+		})
+	})
+
+	Describe("building a flow from a struct", func() {
+		It("allows the steps to be customized", func() {
+			var config struct {
+				EnableTLS bool   `interact:"prompt=Enable TLS?"`
+				CertPath  string `interact:"prompt=Certificate path"`
+			}
+
+			flow, err := interact.StructFlow(&config, interact.WithInput(input), interact.WithOutput(output))
+			Expect(err).ToNot(HaveOccurred())
+
+			flow.Steps[1].When = func() bool { return config.EnableTLS }
+
+			input.WriteString("n\n")
+
+			Expect(flow.Resolve()).To(Succeed())
+			Expect(string(output.Contents())).To(Equal("Enable TLS? [yN]: n\n"))
+		})
+	})
+})
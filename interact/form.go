@@ -0,0 +1,228 @@
+package interact
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// FieldChooser can be implemented by a struct passed to ResolveStruct to
+// provide the choices for its fields, e.g. if they are only known at runtime.
+type FieldChooser interface {
+	// FieldChoices returns the choices for the named field, or nil if the
+	// field is not limited to a set of choices.
+	FieldChoices(field string) []Choice
+}
+
+// ResolveStruct resolves each exported field of the struct pointed to by
+// dst, in order, as if by calling Resolve with a pointer to each field. The
+// field's current value is used as the default.
+//
+// Each field may be configured with an `interact` struct tag, containing a
+// comma-separated list of the following:
+//
+//	prompt=...    the prompt to show; defaults to the field's name
+//	name=...      the Name of the interaction, for finding its answer in
+//	              Answers; defaults to the field's name, prefixed by the
+//	              names of any enclosing fields and a "."
+//	env=...       use the named environment variable as a Source
+//	required      wrap the field in a RequiredDestination
+//	choices=a|b   limit the field to the given choices, each parsed as the
+//	              field's type (or its element type, for slices)
+//
+// A tag of "-" skips the field entirely.
+//
+// Choices may also be provided by implementing FieldChooser. Fields that are
+// themselves structs, and which are not otherwise supported by Resolve, are
+// resolved recursively.
+//
+// Any options are applied to the Interaction for each field.
+func ResolveStruct(dst interface{}, opts ...Option) error {
+	flow, err := StructFlow(dst, opts...)
+	if err != nil {
+		return err
+	}
+
+	return flow.Resolve()
+}
+
+// StructFlow returns a Flow with a Step for each field of the struct pointed
+// to by dst, as described by ResolveStruct. This allows the steps to be
+// customized, e.g. to only ask certain fields depending on earlier answers.
+//
+// The returned flow uses the Input and Output of each step's interaction,
+// which may be set by the given options.
+func StructFlow(dst interface{}, opts ...Option) (Flow, error) {
+	steps, err := structSteps(dst, "", opts)
+	if err != nil {
+		return Flow{}, err
+	}
+
+	return Flow{Steps: steps}, nil
+}
+
+type fieldTag struct {
+	prompt   string
+	name     string
+	env      string
+	required bool
+	choices  []string
+}
+
+func structSteps(dst interface{}, prefix string, opts []Option) ([]Step, error) {
+	ptr := reflect.ValueOf(dst)
+	if ptr.Kind() != reflect.Ptr || ptr.IsNil() || ptr.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("destination must be a pointer to a struct, got %T", dst)
+	}
+
+	chooser, _ := dst.(FieldChooser)
+
+	val := ptr.Elem()
+	typ := val.Type()
+
+	var steps []Step
+	for i := 0; i < typ.NumField(); i++ {
+		fieldType := typ.Field(i)
+		if !fieldType.IsExported() {
+			continue
+		}
+
+		rawTag := fieldType.Tag.Get("interact")
+		if rawTag == "-" {
+			continue
+		}
+
+		tag := parseFieldTag(rawTag)
+
+		fieldPtr := val.Field(i).Addr().Interface()
+
+		name := prefix + fieldType.Name
+		if tag.name != "" {
+			name = prefix + tag.name
+		}
+
+		interaction := NewInteraction(fieldType.Name)
+		interaction.Name = name
+		interaction.apply(opts)
+
+		if tag.env != "" {
+			interaction.Sources = append(interaction.Sources, Env(tag.env))
+		}
+
+		if tag.prompt != "" {
+			interaction.Prompt = tag.prompt
+		}
+
+		if len(tag.choices) > 0 {
+			choices, err := interaction.parseChoices(fieldType.Type, tag.choices)
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %w", fieldType.Name, err)
+			}
+
+			interaction.Choices = choices
+		} else if chooser != nil {
+			interaction.Choices = chooser.FieldChoices(fieldType.Name)
+		}
+
+		_, supported := interaction.parser(fieldPtr)
+
+		if !supported && len(interaction.Choices) == 0 && fieldType.Type.Kind() == reflect.Struct {
+			nested, err := structSteps(fieldPtr, name+".", opts)
+			if err != nil {
+				return nil, err
+			}
+
+			steps = append(steps, nested...)
+			continue
+		}
+
+		var fieldDst interface{} = fieldPtr
+		if tag.required {
+			fieldDst = Required(fieldDst)
+		}
+
+		steps = append(steps, Step{
+			Interaction: interaction,
+			Destination: fieldDst,
+		})
+	}
+
+	return steps, nil
+}
+
+// parseFieldTag parses an `interact` struct tag. Commas not followed by a
+// known option are considered part of the preceding value, so that prompts
+// may contain commas.
+func parseFieldTag(tag string) fieldTag {
+	var parsed fieldTag
+
+	var options []string
+	for _, part := range strings.Split(tag, ",") {
+		if len(options) > 0 && !isFieldTagOption(part) {
+			options[len(options)-1] += "," + part
+		} else {
+			options = append(options, part)
+		}
+	}
+
+	for _, option := range options {
+		key, value, _ := strings.Cut(option, "=")
+
+		switch key {
+		case "prompt":
+			parsed.prompt = value
+		case "name":
+			parsed.name = value
+		case "env":
+			parsed.env = value
+		case "required":
+			parsed.required = true
+		case "choices":
+			parsed.choices = strings.Split(value, "|")
+		}
+	}
+
+	return parsed
+}
+
+func isFieldTagOption(part string) bool {
+	key, _, _ := strings.Cut(part, "=")
+
+	switch key {
+	case "prompt", "name", "env", "required", "choices":
+		return true
+	default:
+		return false
+	}
+}
+
+// parseChoices parses each of the given strings into a value of the given
+// type, or its element type if the type is a slice which cannot be parsed
+// itself.
+func (interaction Interaction) parseChoices(typ reflect.Type, strs []string) ([]Choice, error) {
+	if _, ok := interaction.parser(reflect.New(typ).Interface()); !ok && typ.Kind() == reflect.Slice {
+		typ = typ.Elem()
+	}
+
+	choices := make([]Choice, len(strs))
+	for i, str := range strs {
+		val := reflect.New(typ)
+
+		parse, ok := interaction.parser(val.Interface())
+		if !ok {
+			return nil, fmt.Errorf("unknown choice type: %s", typ)
+		}
+
+		err := parse(str)
+		if err != nil {
+			return nil, fmt.Errorf("invalid choice %q: %w", str, err)
+		}
+
+		choices[i] = Choice{
+			Display: str,
+			Value:   val.Elem().Interface(),
+		}
+	}
+
+	return choices, nil
+}
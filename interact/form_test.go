@@ -0,0 +1,146 @@
+package interact_test
+
+import (
+	"bytes"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+
+	"github.com/vito/go-interact/interact"
+)
+
+type databaseConfig struct {
+	Host string `interact:"prompt=Database host, or IP,required"`
+	Port uint16 `interact:"prompt=Database port"`
+
+	Password interact.Password `interact:"prompt=Database password"`
+}
+
+type serverConfig struct {
+	Name    string
+	Region  string        `interact:"prompt=Region,choices=us-east-1|eu-west-1"`
+	Zones   []int         `interact:"prompt=Zones,choices=1|2|3"`
+	Timeout time.Duration `interact:"prompt=Timeout"`
+	Size    string        `interact:"prompt=Size"`
+
+	Database databaseConfig
+
+	Ignored string `interact:"-"`
+	private string
+}
+
+func (serverConfig) FieldChoices(field string) []interact.Choice {
+	switch field {
+	case "Size":
+		return []interact.Choice{
+			{Display: "Small", Value: "s"},
+			{Display: "Large", Value: "l"},
+		}
+	default:
+		return nil
+	}
+}
+
+var _ = Describe("Resolving structs", func() {
+	var (
+		input  *bytes.Buffer
+		output *gbytes.Buffer
+	)
+
+	BeforeEach(func() {
+		input = new(bytes.Buffer)
+		output = gbytes.NewBuffer()
+	})
+
+	It("resolves each field in order, using the current values as defaults", func() {
+		config := serverConfig{
+			Name:    "web",
+			Region:  "eu-west-1",
+			Zones:   []int{1, 3},
+			Timeout: time.Minute,
+			Size:    "l",
+			Database: databaseConfig{
+				Port: 5432,
+			},
+			Ignored: "ignored",
+			private: "private",
+		}
+
+		input.WriteString("\n1\n2-3\n\n1\n\ndb.example.com\n\nhunter2\n")
+
+		err := interact.ResolveStruct(&config, interact.WithInput(input), interact.WithOutput(output))
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(config).To(Equal(serverConfig{
+			Name:    "web",
+			Region:  "us-east-1",
+			Zones:   []int{2, 3},
+			Timeout: time.Minute,
+			Size:    "s",
+			Database: databaseConfig{
+				Host:     "db.example.com",
+				Port:     5432,
+				Password: "hunter2",
+			},
+			Ignored: "ignored",
+			private: "private",
+		}))
+
+		Expect(string(output.Contents())).To(Equal(
+			"Name (web): \n" +
+				"1: us-east-1\n2: eu-west-1\nRegion (2): 1\n" +
+				"1: 1\n2: 2\n3: 3\nZones (1,3): 2-3\n" +
+				"Timeout (1m0s): \n" +
+				"1: Small\n2: Large\nSize (2): 1\n" +
+				"Database host, or IP: \nDatabase host, or IP: db.example.com\n" +
+				"Database port (5432): \n" +
+				"Database password (): \n",
+		))
+	})
+
+	It("asks for required choices until one is made, without a default", func() {
+		var config struct {
+			Region string   `interact:"required,choices=us|eu"`
+			Zones  []string `interact:"required,choices=a|b|c"`
+		}
+
+		config.Region = "eu"
+
+		input.WriteString("\n2\n\n1,3\n")
+
+		err := interact.ResolveStruct(&config, interact.WithInput(input), interact.WithOutput(output))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(config.Region).To(Equal("eu"))
+		Expect(config.Zones).To(Equal([]string{"a", "c"}))
+
+		Expect(string(output.Contents())).To(Equal(
+			"1: us\n2: eu\nRegion: \nRegion: 2\n" +
+				"1: a\n2: b\n3: c\nZones: \nZones: 1,3\n",
+		))
+	})
+
+	It("returns errors from resolving a field", func() {
+		var config databaseConfig
+
+		input.WriteString("db.example.com\nfoo\n")
+
+		err := interact.ResolveStruct(&config, interact.WithInput(input), interact.WithOutput(output))
+		Expect(err).To(HaveOccurred())
+		Expect(config.Host).To(Equal("db.example.com"))
+	})
+
+	It("returns an error when not given a pointer to a struct", func() {
+		var config databaseConfig
+		Expect(interact.ResolveStruct(config)).To(MatchError("destination must be a pointer to a struct, got interact_test.databaseConfig"))
+	})
+
+	It("returns an error when a choice cannot be parsed", func() {
+		var config struct {
+			Port int `interact:"choices=80|http"`
+		}
+
+		Expect(interact.ResolveStruct(&config)).To(MatchError(`field Port: invalid choice "http": not a number`))
+	})
+})
@@ -0,0 +1,244 @@
+package interact
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+)
+
+// DefaultHistorySize is the number of answers kept for each interaction by a
+// History constructed with NewHistory.
+const DefaultHistorySize = 100
+
+// History keeps the answers previously entered for each interaction in a
+// file, so that they can be recalled with Up and Down on a terminal, even
+// across runs.
+//
+// Answers are kept by the interaction's Name, or its Prompt if it has no
+// name. Passwords are never kept.
+type History struct {
+	// Path is the file in which the history is stored, as JSON.
+	Path string
+
+	// Size is the number of answers kept for each interaction. If zero, there
+	// is no limit.
+	Size int
+
+	mutex sync.Mutex
+}
+
+// NewHistory constructs a history stored in the file at the given path,
+// keeping DefaultHistorySize answers for each interaction.
+func NewHistory(path string) *History {
+	return &History{
+		Path: path,
+		Size: DefaultHistorySize,
+	}
+}
+
+// UserHistory constructs a history stored in the user's state directory, in
+// a directory named after the given app, e.g.
+// ~/.local/state/app/history.json.
+//
+// The state directory is $XDG_STATE_HOME if set, or ~/.local/state on Unix,
+// or the user's config directory elsewhere.
+func UserHistory(app string) (*History, error) {
+	dir, err := stateDir()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewHistory(filepath.Join(dir, app, "history.json")), nil
+}
+
+// Entries returns the answers kept for the interaction with the given name,
+// oldest first.
+func (history *History) Entries(name string) ([]string, error) {
+	history.mutex.Lock()
+	defer history.mutex.Unlock()
+
+	entries, err := history.load()
+	if err != nil {
+		return nil, err
+	}
+
+	return entries[name], nil
+}
+
+// Add adds an answer for the interaction with the given name, moving it to
+// the end if it was already kept, and forgetting the oldest answers if there
+// are more than Size.
+func (history *History) Add(name string, entry string) error {
+	history.mutex.Lock()
+	defer history.mutex.Unlock()
+
+	entries, err := history.load()
+	if err != nil {
+		return err
+	}
+
+	kept := []string{}
+	for _, existing := range entries[name] {
+		if existing != entry {
+			kept = append(kept, existing)
+		}
+	}
+
+	kept = append(kept, entry)
+
+	if history.Size > 0 && len(kept) > history.Size {
+		kept = kept[len(kept)-history.Size:]
+	}
+
+	entries[name] = kept
+
+	return history.save(entries)
+}
+
+func (history *History) load() (map[string][]string, error) {
+	entries := map[string][]string{}
+
+	payload, err := os.ReadFile(history.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return entries, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(payload, &entries)
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// save writes the history to a temporary file which replaces the original,
+// so that it is never left half-written.
+func (history *History) save(entries map[string][]string) error {
+	payload, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(history.Path)
+
+	err = os.MkdirAll(dir, 0700)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, filepath.Base(history.Path)+".*")
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(payload)
+	if err != nil {
+		tmp.Close()
+		return err
+	}
+
+	err = tmp.Close()
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), history.Path)
+}
+
+func stateDir() (string, error) {
+	if dir := os.Getenv("XDG_STATE_HOME"); dir != "" {
+		return dir, nil
+	}
+
+	switch runtime.GOOS {
+	case "windows", "darwin", "ios", "plan9":
+		return os.UserConfigDir()
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(home, ".local", "state"), nil
+}
+
+// historyKey identifies the interaction in its History.
+func (interaction Interaction) historyKey() string {
+	if interaction.Name != "" {
+		return interaction.Name
+	}
+
+	return interaction.Prompt
+}
+
+// recall lets the user step through previous answers with Up and Down.
+type recall struct {
+	entries []string
+
+	// index is the entry being shown, or len(entries) if none is
+	index int
+
+	// pending is the line the user was entering before stepping back
+	pending string
+}
+
+func (r *recall) reset(entries []string) {
+	r.entries = entries
+	r.index = len(entries)
+	r.pending = ""
+}
+
+// callback handles the keys translated by a keyReader.
+func (r *recall) callback(line string, pos int, key rune) (string, int, bool) {
+	switch key {
+	case keyRecallPrevious:
+		if r.index == 0 {
+			return line, pos, true
+		}
+
+		if r.index == len(r.entries) {
+			r.pending = line
+		}
+
+		r.index--
+
+	case keyRecallNext:
+		if r.index == len(r.entries) {
+			return line, pos, true
+		}
+
+		r.index++
+
+	default:
+		return "", 0, false
+	}
+
+	entry := r.pending
+	if r.index < len(r.entries) {
+		entry = r.entries[r.index]
+	}
+
+	return entry, len(entry), true
+}
+
+// ignoreRecall swallows the keys translated for recalling answers, e.g.
+// when reading a password.
+func ignoreRecall(line string, pos int, key rune) (string, int, bool) {
+	switch key {
+	case keyRecallPrevious, keyRecallNext:
+		return line, pos, true
+	default:
+		return "", 0, false
+	}
+}
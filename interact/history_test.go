@@ -0,0 +1,207 @@
+package interact_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vito/go-interact/interact"
+	"github.com/vito/go-interact/interact/interacttest"
+)
+
+var _ = Describe("History", func() {
+	var (
+		path    string
+		history *interact.History
+	)
+
+	BeforeEach(func() {
+		path = filepath.Join(GinkgoT().TempDir(), "state", "history.json")
+		history = interact.NewHistory(path)
+	})
+
+	It("is empty before anything is added", func() {
+		Expect(history.Entries("branch")).To(BeEmpty())
+	})
+
+	It("keeps answers for each interaction, oldest first, across instances", func() {
+		Expect(history.Add("branch", "main")).To(Succeed())
+		Expect(history.Add("branch", "develop")).To(Succeed())
+		Expect(history.Add("cluster", "staging")).To(Succeed())
+
+		reloaded := interact.NewHistory(path)
+		Expect(reloaded.Entries("branch")).To(Equal([]string{"main", "develop"}))
+		Expect(reloaded.Entries("cluster")).To(Equal([]string{"staging"}))
+	})
+
+	It("moves repeated answers to the end", func() {
+		Expect(history.Add("branch", "main")).To(Succeed())
+		Expect(history.Add("branch", "develop")).To(Succeed())
+		Expect(history.Add("branch", "main")).To(Succeed())
+
+		Expect(history.Entries("branch")).To(Equal([]string{"develop", "main"}))
+	})
+
+	It("forgets the oldest answers beyond its size", func() {
+		history.Size = 2
+
+		Expect(history.Add("branch", "a")).To(Succeed())
+		Expect(history.Add("branch", "b")).To(Succeed())
+		Expect(history.Add("branch", "c")).To(Succeed())
+
+		Expect(history.Entries("branch")).To(Equal([]string{"b", "c"}))
+	})
+
+	It("returns an error if the file is not valid", func() {
+		Expect(os.MkdirAll(filepath.Dir(path), 0755)).To(Succeed())
+		Expect(os.WriteFile(path, []byte("nope"), 0644)).To(Succeed())
+
+		_, err := history.Entries("branch")
+		Expect(err).To(HaveOccurred())
+	})
+
+	Describe("UserHistory", func() {
+		It("is stored in $XDG_STATE_HOME", func() {
+			GinkgoT().Setenv("XDG_STATE_HOME", "/some/state")
+
+			history, err := interact.UserHistory("myapp")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(history.Path).To(Equal(filepath.Join("/some/state", "myapp", "history.json")))
+			Expect(history.Size).To(Equal(interact.DefaultHistorySize))
+		})
+	})
+
+	Context("on a terminal", func() {
+		var term *interacttest.Terminal
+
+		BeforeEach(func() {
+			term = interacttest.NewTerminal(GinkgoT())
+		})
+
+		resolve := func(prompt string, dst interface{}) {
+			interaction := term.Interaction(prompt)
+			interaction.History = history
+
+			term.Start(func() error {
+				return interaction.Resolve(dst)
+			})
+		}
+
+		It("keeps what was entered, and recalls it with Up and Down", func() {
+			Expect(history.Add("Branch", "main")).To(Succeed())
+			Expect(history.Add("Branch", "develop")).To(Succeed())
+
+			var branch string
+			resolve("Branch", interact.Required(&branch))
+
+			term.ExpectCursorLine("Branch:")
+			term.Type("fea")
+
+			term.Press(interacttest.KeyUp)
+			term.ExpectCursorLine("Branch: develop")
+
+			term.Press(interacttest.KeyUp)
+			term.ExpectCursorLine("Branch: main")
+
+			term.Press(interacttest.KeyUp)
+			term.ExpectCursorLine("Branch: main")
+
+			term.Press(interacttest.KeyDown)
+			term.ExpectCursorLine("Branch: develop")
+
+			term.Press(interacttest.KeyDown)
+			term.ExpectCursorLine("Branch: fea")
+
+			term.Type("ture")
+			term.Press(interacttest.KeyEnter)
+			Expect(term.Wait()).To(Succeed())
+			Expect(branch).To(Equal("feature"))
+
+			Expect(history.Entries("Branch")).To(Equal([]string{"main", "develop", "feature"}))
+		})
+
+		It("only keeps answers that were accepted", func() {
+			var count int
+			resolve("Count", interact.Required(&count))
+
+			term.ExpectCursorLine("Count:")
+			term.Type("abc")
+			term.Press(interacttest.KeyEnter)
+
+			term.ExpectScreen("invalid input (not a number)")
+			term.Type("2")
+			term.Press(interacttest.KeyEnter)
+			Expect(term.Wait()).To(Succeed())
+			Expect(count).To(Equal(2))
+
+			Expect(history.Entries("Count")).To(Equal([]string{"2"}))
+		})
+
+		It("lets the user answer even if the history cannot be loaded", func() {
+			Expect(os.MkdirAll(filepath.Dir(path), 0755)).To(Succeed())
+			Expect(os.WriteFile(path, []byte("nope"), 0644)).To(Succeed())
+
+			var branch string
+			resolve("Branch", interact.Required(&branch))
+
+			term.ExpectCursorLine("Branch:")
+			term.Type("main")
+			term.Press(interacttest.KeyUp)
+			term.ExpectCursorLine("Branch: main")
+
+			term.Press(interacttest.KeyEnter)
+			Expect(term.Wait()).To(Succeed())
+			Expect(branch).To(Equal("main"))
+		})
+
+		It("accepts the answer even if the history cannot be saved", func() {
+			// the history's directory cannot be created where a file is
+			Expect(os.WriteFile(filepath.Dir(path), []byte("nope"), 0644)).To(Succeed())
+
+			var branch string
+			resolve("Branch", interact.Required(&branch))
+
+			term.ExpectCursorLine("Branch:")
+			term.Type("main")
+			term.Press(interacttest.KeyEnter)
+			Expect(term.Wait()).To(Succeed())
+			Expect(branch).To(Equal("main"))
+		})
+
+		It("keeps answers by the interaction's name, if it has one", func() {
+			interaction := term.Interaction("Branch")
+			interaction.Name = "branch"
+			interaction.History = history
+
+			var branch string
+			term.Start(func() error {
+				return interaction.Resolve(interact.Required(&branch))
+			})
+
+			term.ExpectCursorLine("Branch:")
+			term.Type("main")
+			term.Press(interacttest.KeyEnter)
+			Expect(term.Wait()).To(Succeed())
+
+			Expect(history.Entries("branch")).To(Equal([]string{"main"}))
+		})
+
+		It("never keeps passwords, nor recalls answers into them", func() {
+			Expect(history.Add("Password", "oops")).To(Succeed())
+
+			var password interact.Password
+			resolve("Password", interact.Required(&password))
+
+			term.ExpectCursorLine("Password:")
+			term.Press(interacttest.KeyUp)
+			term.Type("hunter2")
+			term.Press(interacttest.KeyEnter)
+			Expect(term.Wait()).To(Succeed())
+			Expect(password).To(Equal(interact.Password("hunter2")))
+
+			Expect(history.Entries("Password")).To(Equal([]string{"oops"}))
+		})
+	})
+})
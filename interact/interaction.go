@@ -1,11 +1,14 @@
 package interact
 
 import (
+	"context"
+	"encoding"
 	"fmt"
 	"io"
 	"os"
 	"reflect"
-	"strconv"
+	"strings"
+	"time"
 
 	"golang.org/x/term"
 )
@@ -16,8 +19,73 @@ type Interaction struct {
 	Prompt  string
 	Choices []Choice
 
+	// TimeLayout is the layout used for parsing and showing time.Time values.
+	// If empty, DefaultTimeLayout is used.
+	TimeLayout string
+
+	// Validate, if set, is called with the value the user entered (or the
+	// default, if they entered nothing) before it is stored in the
+	// destination. If it returns an error, the error is shown to the user and
+	// they are asked again.
+	Validate func(value interface{}) error
+
+	// Name identifies the interaction, e.g. for finding its answer in
+	// Answers.
+	Name string
+
+	// Answers, if it has an answer for the interaction's Name, is used to
+	// resolve the destination without prompting the user.
+	Answers Answers
+
+	// Sources, if any, are checked in order for a value given explicitly,
+	// e.g. by an environment variable or flag, which is used instead of
+	// prompting the user. If none are given, the user is asked as usual, unless
+	// the input is not a terminal, in which case Resolve returns a
+	// MissingValueError.
+	Sources []Source
+
+	// NonInteractive determines what happens when the input is not a
+	// terminal. By default, the answer is read from the input anyway.
+	NonInteractive NonInteractivePolicy
+
+	// Recorder, if set, records a transcript of the user's answers.
+	Recorder *Recorder
+
+	// Replayer, if set, answers with the input from a recorded transcript
+	// instead of reading from Input.
+	Replayer *Replayer
+
+	// Complete, if set, is called when the user presses Tab on a terminal,
+	// with the line entered so far and the position of the cursor in it (in
+	// bytes). It returns the candidates for the text before the cursor, each of
+	// which replaces it. See CompleteWords, CompleteChoices, and CompletePaths.
+	Complete func(line string, pos int) []string
+
+	// History, if set, keeps the answers entered on a terminal, so that they
+	// can be recalled with Up and Down the next time the interaction is
+	// resolved. A history which cannot be loaded or saved is ignored.
+	History *History
+
+	// EditDefault, if true, starts the line out with the default on a
+	// terminal, for the user to edit in place, rather than showing the
+	// default in the prompt. As usual, entering a blank line keeps the
+	// default. Bools, passwords, and choices are asked as usual.
+	EditDefault bool
+
+	// Editor, if set, opens the user's editor to enter Multiline answers on a
+	// terminal.
+	Editor *Editor
+
+	// Protocol determines how the question is asked and answered. By
+	// default, it is asked as a human-readable prompt.
+	Protocol Protocol
+
 	Input  io.Reader
 	Output io.Writer
+
+	// back is the input that signals going back to the previous step of a
+	// Flow, if any.
+	back string
 }
 
 // NewInteraction constructs an interaction with the given prompt, limited to
@@ -48,42 +116,194 @@ func NewInteraction(prompt string, choices ...Choice) Interaction {
 // to the choice will be the default value shown to the user. If no default is
 // found, Resolve will require the user to make a selection.
 //
+// When the input is a terminal, the choices are shown as a menu instead, which
+// the user navigates with the arrow keys (or j and k), selecting with enter.
+// The default choice starts out highlighted. Typing / allows the user to
+// filter the choices by fuzzy-matching text against their Display values, as
+// the menu's header hints.
+//
+// If dst is a pointer to a slice, and the choices' values are elements of
+// that slice, the user may select multiple choices, e.g. "1,3" or "2-4", or
+// by toggling them with space when shown as a menu. The default is inferred
+// from the current contents of the slice.
+//
 // The type of dst determines how the value is read. Currently supported types
-// for the destination are int, string, bool, and any arbitrary value that is
-// defined within the set of Choices.
+// for the destination are string, bool, Password, Multiline, any sized int,
+// uint, float, or complex number, time.Duration, time.Time, any type
+// implementing Resolver or encoding.TextUnmarshaler, and any arbitrary value
+// that is defined within the set of Choices.
 //
 // Valid input strings for bools are "y", "n", "Y", "N", "yes", and "no".
-// Integer values are parsed in base-10. String values will not include any
-// trailing linebreak.
+// Integer values are parsed in base-10, and must fit in the destination's
+// type. String values will not include any trailing linebreak. Multiline
+// values may span several lines; see Multiline for how they are ended.
+//
+// Durations are parsed with time.ParseDuration, e.g. "1h30m". Times are
+// parsed and shown using the interaction's TimeLayout, and may also be entered
+// relative to the current time, e.g. "now", "+2h", or "-30m".
+//
+// Values implementing encoding.TextUnmarshaler are parsed with UnmarshalText.
+// Their default is shown using MarshalText if they implement
+// encoding.TextMarshaler, or String if they implement fmt.Stringer.
+//
+// If one of the interaction's Sources was given, or its Answers has an answer
+// for its Name, the destination is resolved from that instead, without
+// prompting. An invalid value is returned as an error rather than asking
+// again.
+//
+// If the input is not a terminal, the interaction's NonInteractive policy
+// determines whether the answer is read from the input, the default is used,
+// or ErrNotInteractive is returned.
 func (interaction Interaction) Resolve(dst interface{}) error {
+	return interaction.ResolveContext(context.Background(), dst)
+}
+
+// ResolveContext is like Resolve, but gives up on waiting for the user once
+// ctx is done, returning ctx.Err().
+//
+// If the input is a terminal, it is restored from raw mode before returning,
+// and the prompt's line is ended. Where the Input is a file that can be
+// polled, as terminals on Unix can, nothing is left reading from it.
+// Otherwise the read that was pending when ctx ended may still complete in
+// the background; whatever it reads goes to the next interaction reading
+// from the same Input if it has started by then, and is dropped if not.
+func (interaction Interaction) ResolveContext(ctx context.Context, dst interface{}) error {
+	_, err := interaction.resolveContext(ctx, dst)
+	return err
+}
+
+// resolveContext is like ResolveContext, also returning false if dst was
+// resolved without asking the user, e.g. from a Source or Answers.
+func (interaction Interaction) resolveContext(ctx context.Context, dst interface{}) (bool, error) {
+	asked, err := interaction.resolve(ctx, dst)
+	if err != nil && ctx.Err() != nil {
+		return asked, ctx.Err()
+	}
+
+	return asked, err
+}
+
+func (interaction Interaction) resolve(ctx context.Context, dst interface{}) (bool, error) {
+	if value, source, found := interaction.lookupSource(); found {
+		return false, interaction.resolveSource(dst, source, value)
+	}
+
+	if answer, found := interaction.answer(); found {
+		return false, interaction.resolveAnswer(dst, answer)
+	}
+
+	if interaction.Replayer != nil {
+		return true, interaction.Replayer.replay(interaction, dst)
+	}
+
+	if interaction.Protocol != ProtocolJSON && !interaction.isTerminal() {
+		switch interaction.NonInteractive {
+		case NonInteractivePrompt, NonInteractiveFail:
+			if len(interaction.Sources) > 0 {
+				return false, MissingValueError{
+					Prompt:  interaction.Prompt,
+					Sources: interaction.Sources,
+				}
+			}
+		}
+
+		if interaction.NonInteractive != NonInteractivePrompt {
+			return false, interaction.resolveNonInteractive(dst)
+		}
+	}
+
 	prompt := interaction.prompt(dst)
 
+	// always read through a contextReader, which picks up any read left
+	// pending by an earlier interaction that was given up on
+	input := newContextReader(ctx, interaction.Input)
+	defer abandonRead(interaction.Input)
+
+	lineBreak := "\n"
+
+	// keep, if set, is called once the answer has been accepted
+	var keep func()
+
 	var user userIO
-	if input, output, ok := interaction.getStreams(); ok && term.IsTerminal(int(input.Fd())) {
-		state, err := term.MakeRaw(int(input.Fd()))
+	if interaction.Protocol == ProtocolJSON {
+		user = newJSONUser(interaction, dst, input, interaction.Output)
+	} else if file, output, ok := interaction.getStreams(); ok && term.IsTerminal(int(file.Fd())) {
+		state, err := term.MakeRaw(int(file.Fd()))
 		if err != nil {
-			return err
+			return false, err
 		}
 
-		defer term.Restore(int(input.Fd()), state)
+		defer term.Restore(int(file.Fd()), state)
 
-		term, err := newTTYUser(input, output)
+		tty, err := newTTYUser(interaction, input, output)
 		if err != nil {
-			return err
+			return false, err
+		}
+
+		tty.file, tty.state = file, state
+
+		lineBreak = "\r\n"
+		keep = tty.keepAnswer
+
+		if interaction.EditDefault {
+			if def, ok := interaction.editableDefault(dst); ok {
+				prompt = fmt.Sprintf("%s: ", interaction.Prompt)
+				tty.prefill = &def
+			}
 		}
 
-		user = term
+		user = tty
+	} else {
+		nonTTY := newNonTTYUser(input, interaction.Output)
+		nonTTY.back = interaction.back
+
+		user = nonTTY
+	}
+
+	var err error
+	if interaction.Recorder != nil {
+		err = interaction.Recorder.record(interaction, dst, user, prompt)
 	} else {
-		user = newNonTTYUser(interaction.Input, interaction.Output)
+		err = interaction.resolveWith(dst, user, prompt)
+	}
+
+	if err == nil && keep != nil {
+		keep()
 	}
 
+	if err != nil && ctx.Err() != nil {
+		// end the line the user was given up on, so that whatever is printed
+		// next does not land on it
+		fmt.Fprint(interaction.Output, lineBreak)
+	}
+
+	return true, err
+}
+
+// resolveWith resolves dst by asking the given user.
+func (interaction Interaction) resolveWith(dst interface{}, user userIO, prompt string) error {
 	if len(interaction.Choices) == 0 {
 		return interaction.resolveSingle(dst, user, prompt)
 	}
 
+	choiceDst := dst
+	if required, ok := dst.(RequiredDestination); ok {
+		choiceDst = required.Destination
+	}
+
+	if interaction.isMultipleChoice(choiceDst) {
+		return interaction.resolveMultipleChoices(dst, user, prompt)
+	}
+
 	return interaction.resolveChoices(dst, user, prompt)
 }
 
+// isTerminal returns true if the user can be asked interactively.
+func (interaction Interaction) isTerminal() bool {
+	file, _, ok := interaction.getStreams()
+	return ok && term.IsTerminal(int(file.Fd()))
+}
+
 func (interaction Interaction) getStreams() (*os.File, *os.File, bool) {
 	input, inputConverted := interaction.Input.(*os.File)
 	output, outputConverted := interaction.Output.(*os.File)
@@ -91,6 +311,15 @@ func (interaction Interaction) getStreams() (*os.File, *os.File, bool) {
 }
 
 func (interaction Interaction) prompt(dst interface{}) string {
+	if interaction.isMultipleChoice(dst) {
+		nums, present := interaction.choiceNumbers(dst)
+		if present {
+			return fmt.Sprintf("%s (%s): ", interaction.Prompt, formatSelection(nums))
+		}
+
+		return fmt.Sprintf("%s: ", interaction.Prompt)
+	}
+
 	if len(interaction.Choices) > 0 {
 		num, present := interaction.choiceNumber(dst)
 		if present {
@@ -108,10 +337,6 @@ func (interaction Interaction) prompt(dst interface{}) string {
 		default:
 			return fmt.Sprintf("%s: ", interaction.Prompt)
 		}
-	case *int:
-		return fmt.Sprintf("%s (%d): ", interaction.Prompt, *v)
-	case *string:
-		return fmt.Sprintf("%s (%s): ", interaction.Prompt, *v)
 	case *bool:
 		var indicator string
 		if *v {
@@ -127,20 +352,118 @@ func (interaction Interaction) prompt(dst interface{}) string {
 		}
 
 		return fmt.Sprintf("%s (has default): ", interaction.Prompt)
-	default:
+	case *Multiline:
+		// only show the first line of the default
+		def, _, multiple := strings.Cut(string(*v), "\n")
+		if multiple {
+			def += " …"
+		}
+
+		return fmt.Sprintf("%s (%s): ", interaction.Prompt, def)
+	}
+
+	def, ok := interaction.format(dst)
+	if !ok {
+		if _, known := interaction.parser(dst); known {
+			// no way to show the default, e.g. a TextUnmarshaler that is not
+			// a TextMarshaler
+			return fmt.Sprintf("%s: ", interaction.Prompt)
+		}
+
 		return fmt.Sprintf("%s (unknown): ", interaction.Prompt)
 	}
+
+	return fmt.Sprintf("%s (%s): ", interaction.Prompt, def)
+}
+
+// editableDefault returns the default to start the line out with when
+// EditDefault is set, or false if dst is asked as usual.
+func (interaction Interaction) editableDefault(dst interface{}) (string, bool) {
+	if len(interaction.Choices) > 0 {
+		return "", false
+	}
+
+	switch dst.(type) {
+	case RequiredDestination, *bool, *Password, *Multiline:
+		return "", false
+	}
+
+	return interaction.format(dst)
+}
+
+// format returns the value held in dst as the user would enter it, or false
+// if it cannot be shown.
+func (interaction Interaction) format(dst interface{}) (string, bool) {
+	switch v := dst.(type) {
+	case Resolver:
+		return v.MarshalInput(), true
+	case *time.Duration:
+		return v.String(), true
+	case *time.Time:
+		return v.Format(interaction.timeLayout()), true
+	case encoding.TextUnmarshaler:
+		return textDefault(v)
+	case *string:
+		return *v, true
+	case *Password:
+		return string(*v), true
+	case *Multiline:
+		return string(*v), true
+	case *bool:
+		if *v {
+			return "yes", true
+		}
+
+		return "no", true
+	}
+
+	if val, ok := numberValue(dst); ok {
+		return formatNumber(val), true
+	}
+
+	return "", false
+}
+
+func textDefault(dst interface{}) (string, bool) {
+	switch v := dst.(type) {
+	case encoding.TextMarshaler:
+		text, err := v.MarshalText()
+		if err != nil {
+			return "", false
+		}
+
+		return string(text), true
+	case fmt.Stringer:
+		return v.String(), true
+	default:
+		return "", false
+	}
 }
 
+// choiceNumber returns the number of the choice held in dst. A
+// RequiredDestination has no default, so none is found.
 func (interaction Interaction) choiceNumber(dst interface{}) (int, bool) {
+	if _, ok := dst.(RequiredDestination); ok {
+		return 0, false
+	}
+
+	return interaction.choiceNumberOf(reflect.ValueOf(dst).Elem())
+}
+
+func (interaction Interaction) choiceNumberOf(val reflect.Value) (int, bool) {
 	for i, c := range interaction.Choices {
-		dstVal := reflect.ValueOf(dst).Elem()
+		if c.Value == nil {
+			switch val.Kind() {
+			case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Ptr, reflect.Slice:
+				if val.IsNil() {
+					return i + 1, true
+				}
+			}
 
-		if c.Value == nil && dstVal.IsNil() {
-			return i + 1, true
+			continue
 		}
 
-		if reflect.DeepEqual(c.Value, dstVal.Interface()) {
+		if reflect.DeepEqual(c.Value, val.Interface()) {
 			return i + 1, true
 		}
 	}
@@ -150,7 +473,7 @@ func (interaction Interaction) choiceNumber(dst interface{}) (int, bool) {
 
 func (interaction Interaction) resolveSingle(dst interface{}, user userIO, prompt string) error {
 	for {
-		_, retry, err := interaction.readInto(dst, user, prompt)
+		_, retry, err := interaction.readValid(dst, user, prompt)
 		if err == io.EOF {
 			return err
 		}
@@ -172,62 +495,192 @@ func (interaction Interaction) resolveSingle(dst interface{}, user userIO, promp
 
 func (interaction Interaction) resolveChoices(dst interface{}, user userIO, prompt string) error {
 	dstVal := reflect.ValueOf(dst)
+	if required, ok := dst.(RequiredDestination); ok {
+		dstVal = reflect.ValueOf(required.Destination)
+	}
 
-	for i, choice := range interaction.Choices {
-		err := user.WriteLine(fmt.Sprintf("%d: %s", i+1, choice.Display))
+	menu, isMenu := user.(selector)
+	if !isMenu {
+		err := interaction.listChoices(user)
 		if err != nil {
 			return err
 		}
 	}
 
 	for {
-		var retry bool
+		var num int
 		var err error
-
-		num, present := interaction.choiceNumber(dst)
-		if present {
-			_, retry, err = interaction.readInto(&num, user, prompt)
+		if isMenu {
+			num, err = interaction.selectChoice(dst, menu)
 		} else {
-			_, retry, err = interaction.readInto(Required(&num), user, prompt)
+			num, err = interaction.readChoice(dst, user, prompt)
 		}
 
-		if err == io.EOF {
+		if err != nil {
 			return err
 		}
 
+		if num == 0 {
+			continue
+		}
+
+		choiceVal, err := interaction.choiceValue(num, dstVal.Type().Elem())
 		if err != nil {
-			if retry {
+			return err
+		}
+
+		if interaction.Validate != nil {
+			err := interaction.Validate(choiceVal.Interface())
+			if err != nil {
 				user.WriteLine(fmt.Sprintf("invalid selection (%s)", err))
 				continue
-			} else {
-				return err
 			}
 		}
 
-		if num == 0 || num > len(interaction.Choices) {
-			user.WriteLine(fmt.Sprintf("invalid selection (must be 1-%d)", len(interaction.Choices)))
-			continue
+		dstVal.Elem().Set(choiceVal)
+
+		return nil
+	}
+}
+
+// choiceValue returns the value of the given choice number, to be assigned to
+// a value of the given type.
+func (interaction Interaction) choiceValue(num int, typ reflect.Type) (reflect.Value, error) {
+	choice := interaction.Choices[num-1]
+
+	if choice.Value == nil {
+		return reflect.Zero(typ), nil
+	}
+
+	choiceVal := reflect.ValueOf(choice.Value)
+
+	if !choiceVal.Type().AssignableTo(typ) {
+		return reflect.Value{}, NotAssignableError{
+			Value:       choiceVal.Type(),
+			Destination: typ,
 		}
+	}
 
-		choice := interaction.Choices[num-1]
+	return choiceVal, nil
+}
 
-		if choice.Value == nil {
-			dstVal.Elem().Set(reflect.Zero(dstVal.Type().Elem()))
-		} else {
-			choiceVal := reflect.ValueOf(choice.Value)
+// readChoice asks the user to enter the number of a choice. If the input is
+// not valid, it tells the user why and returns 0 so they can be asked again.
+func (interaction Interaction) readChoice(dst interface{}, user userIO, prompt string) (int, error) {
+	var retry bool
+	var err error
 
-			if choiceVal.Type().AssignableTo(dstVal.Type().Elem()) {
-				dstVal.Elem().Set(choiceVal)
-			} else {
-				return NotAssignableError{
-					Value:       choiceVal.Type(),
-					Destination: dstVal.Type().Elem(),
-				}
-			}
+	num, present := interaction.choiceNumber(dst)
+	if present {
+		_, retry, err = interaction.readInto(&num, user, prompt)
+	} else {
+		_, retry, err = interaction.readInto(Required(&num), user, prompt)
+	}
+
+	if err != nil {
+		if retry {
+			user.WriteLine(fmt.Sprintf("invalid selection (%s)", err))
+			return 0, nil
 		}
 
-		return nil
+		return 0, err
+	}
+
+	if num <= 0 || num > len(interaction.Choices) {
+		user.WriteLine(fmt.Sprintf("invalid selection (must be 1-%d)", len(interaction.Choices)))
+		return 0, nil
+	}
+
+	return num, nil
+}
+
+// selectChoice presents the choices as a menu, with the default choice
+// initially highlighted.
+func (interaction Interaction) selectChoice(dst interface{}, menu selector) (int, error) {
+	initial := 0
+	if num, present := interaction.choiceNumber(dst); present {
+		initial = num - 1
+	}
+
+	idx, err := menu.Select(interaction.Prompt, interaction.choiceDisplays(), initial)
+	if err != nil {
+		return 0, err
+	}
+
+	return idx + 1, nil
+}
+
+func (interaction Interaction) choiceDisplays() []string {
+	displays := make([]string, len(interaction.Choices))
+	for i, choice := range interaction.Choices {
+		displays[i] = choice.Display
+	}
+
+	return displays
+}
+
+func (interaction Interaction) listChoices(user userIO) error {
+	for i, choice := range interaction.Choices {
+		err := user.WriteLine(fmt.Sprintf("%d: %s", i+1, choice.Display))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readValid reads into dst, first checking the value against the
+// interaction's Validate hook, if any.
+//
+// When validating, the value is read into a new zero value of the
+// destination's type, and only stored in dst once it is valid. This way an
+// invalid value never replaces the default.
+func (interaction Interaction) readValid(dst interface{}, user userIO, prompt string) (bool, bool, error) {
+	if interaction.Validate == nil {
+		return interaction.readInto(dst, user, prompt)
+	}
+
+	required, isRequired := dst.(RequiredDestination)
+	if isRequired {
+		dst = required.Destination
+	}
+
+	dstVal := reflect.ValueOf(dst)
+	if dstVal.Kind() != reflect.Ptr || dstVal.IsNil() {
+		return interaction.readInto(dst, user, prompt)
+	}
+
+	scratch := reflect.New(dstVal.Type().Elem())
+
+	if !isRequired && scratch.Elem().Kind() == reflect.String {
+		// start from the default, e.g. when editing it in an editor
+		scratch.Elem().Set(dstVal.Elem())
+	}
+
+	var scratchDst interface{} = scratch.Interface()
+	if isRequired {
+		scratchDst = Required(scratchDst)
+	}
+
+	read, retry, err := interaction.readInto(scratchDst, user, prompt)
+	if err != nil {
+		return read, retry, err
 	}
+
+	value := dstVal.Elem()
+	if read {
+		value = scratch.Elem()
+	}
+
+	err = interaction.Validate(value.Interface())
+	if err != nil {
+		return false, true, err
+	}
+
+	dstVal.Elem().Set(value)
+
+	return read, false, nil
 }
 
 func (interaction Interaction) readInto(dst interface{}, user userIO, prompt string) (bool, bool, error) {
@@ -244,74 +697,137 @@ func (interaction Interaction) readInto(dst interface{}, user userIO, prompt str
 			}
 		}
 
-	case *int:
-		line, err := user.ReadLine(prompt)
+	case *Password:
+		pass, err := user.ReadPassword(prompt)
 		if err != nil {
 			return false, false, err
 		}
 
-		if len(line) == 0 {
+		if len(pass) == 0 {
 			return false, false, nil
 		}
 
-		num, err := strconv.Atoi(line)
-		if err != nil {
-			return false, true, ErrNotANumber
-		}
-
-		*v = num
+		*v = Password(pass)
 
 		return true, false, nil
 
-	case *string:
-		line, err := user.ReadLine(prompt)
+	case *Multiline:
+		text, err := interaction.readLines(string(*v), user, prompt)
 		if err != nil {
 			return false, false, err
 		}
 
-		if len(line) == 0 {
+		if len(text) == 0 {
 			return false, false, nil
 		}
 
-		*v = line
+		*v = Multiline(text)
 
 		return true, false, nil
+	}
 
-	case *Password:
-		pass, err := user.ReadPassword(prompt)
-		if err != nil {
-			return false, false, err
-		}
+	parse, ok := interaction.parser(dst)
+	if !ok {
+		return false, false, fmt.Errorf("unknown destination type: %T", dst)
+	}
 
-		if len(pass) == 0 {
-			return false, false, nil
-		}
+	return readParsed(user, prompt, parse)
+}
 
-		*v = Password(pass)
+// parser returns a function that parses a line of input into dst, or false
+// if the type of dst is not supported.
+func (interaction Interaction) parser(dst interface{}) (func(string) error, bool) {
+	switch v := dst.(type) {
+	case Resolver:
+		return v.UnmarshalInput, true
 
-		return true, false, nil
+	case *time.Duration:
+		return func(line string) error {
+			duration, err := time.ParseDuration(line)
+			if err != nil {
+				return err
+			}
+
+			*v = duration
+
+			return nil
+		}, true
+
+	case *time.Time:
+		return func(line string) error {
+			t, err := parseTime(line, interaction.timeLayout(), time.Now())
+			if err != nil {
+				return err
+			}
+
+			*v = t
+
+			return nil
+		}, true
+
+	case encoding.TextUnmarshaler:
+		return func(line string) error {
+			return v.UnmarshalText([]byte(line))
+		}, true
+
+	case *string:
+		return func(line string) error {
+			*v = line
+			return nil
+		}, true
+
+	case *Password:
+		return func(line string) error {
+			*v = Password(line)
+			return nil
+		}, true
+
+	case *Multiline:
+		return func(line string) error {
+			*v = Multiline(line)
+			return nil
+		}, true
 
 	case *bool:
-		line, err := user.ReadLine(prompt)
-		if err != nil {
-			return false, false, err
-		}
+		return func(line string) error {
+			switch line {
+			case "y", "Y", "yes":
+				*v = true
+			case "n", "N", "no":
+				*v = false
+			default:
+				return ErrNotBoolean
+			}
 
-		if len(line) == 0 {
-			return false, false, nil
-		}
+			return nil
+		}, true
+	}
 
-		switch line {
-		case "y", "Y", "yes":
-			*v = true
-		case "n", "N", "no":
-			*v = false
-		default:
-			return false, true, ErrNotBoolean
-		}
+	if val, ok := numberValue(dst); ok {
+		return func(line string) error {
+			return parseNumber(val, line)
+		}, true
+	}
 
-		return true, false, nil
+	return nil, false
+}
+
+// readParsed reads a line and, if it is not blank, passes it to parse. Any
+// error returned by parse is considered retryable.
+func readParsed(user userIO, prompt string, parse func(string) error) (bool, bool, error) {
+	line, err := user.ReadLine(prompt)
+	if err != nil {
+		return false, false, err
+	}
+
+	if len(line) == 0 {
+		return false, false, nil
+	}
+
+	err = parse(line)
+	if err != nil {
+		return false, true, err
 	}
 
-	return false, false, fmt.Errorf("unknown destination type: %T", dst)
+	return true, false, nil
 }
@@ -0,0 +1,339 @@
+// Package interacttest provides a scripted conversation for testing code that
+// asks the user questions with the interact package.
+//
+// Rather than comparing the entire output at the end, the test plays the part
+// of the user, expecting each prompt in turn and sending answers:
+//
+//	conv := interacttest.New(t)
+//
+//	var username, number string
+//	conv.Start(func() error {
+//		err := conv.Interaction("Username").Resolve(interact.Required(&username))
+//		if err != nil {
+//			return err
+//		}
+//
+//		return conv.Interaction("Number", choices...).Resolve(&number)
+//	})
+//
+//	conv.Expect("Username: ")
+//	conv.Send("alice")
+//	conv.SendChoice("Dos")
+//
+//	if err := conv.Wait(); err != nil {
+//		t.Fatal(err)
+//	}
+//
+// If the conversation goes off script, the test fails, showing what was
+// expected and what was printed instead.
+package interacttest
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vito/go-interact/interact"
+)
+
+// DefaultTimeout is how long a Conversation waits for the expected output by
+// default.
+const DefaultTimeout = 5 * time.Second
+
+// T is the subset of testing.TB used to fail the test.
+type T interface {
+	Helper()
+	Fatalf(format string, args ...interface{})
+}
+
+// TB is the subset of testing.TB used by Terminal, which also needs to clean
+// up after the test.
+type TB interface {
+	T
+	Cleanup(func())
+}
+
+// Conversation plays the part of the user in a test.
+type Conversation struct {
+	// Timeout is how long to wait for expected output, or for the
+	// conversation to finish.
+	Timeout time.Duration
+
+	t T
+
+	input  *buffer
+	output *buffer
+
+	// cursor is the offset into the output up to which it has been
+	// expected
+	cursor int
+
+	runner
+}
+
+// New constructs a conversation which fails the given test when it goes off
+// script.
+func New(t T) *Conversation {
+	return &Conversation{
+		Timeout: DefaultTimeout,
+
+		t: t,
+
+		input:  newBuffer(),
+		output: newBuffer(),
+	}
+}
+
+// Input returns the reader from which the user's answers are read.
+func (conv *Conversation) Input() io.Reader {
+	return conv.input
+}
+
+// Output returns the writer to which the questions are written.
+func (conv *Conversation) Output() io.Writer {
+	return conv.output
+}
+
+// Interaction constructs an interaction which uses the conversation's input
+// and output.
+func (conv *Conversation) Interaction(prompt string, choices ...interact.Choice) interact.Interaction {
+	interaction := interact.NewInteraction(prompt, choices...)
+	interaction.Input = conv.Input()
+	interaction.Output = conv.Output()
+	return interaction
+}
+
+// Start calls fn in the background, which should ask questions using the
+// conversation's input and output. Its error is returned by Wait.
+func (conv *Conversation) Start(fn func() error) {
+	conv.start(fn)
+}
+
+// Expect waits for the given text to be printed after the text expected so
+// far.
+func (conv *Conversation) Expect(text string) {
+	conv.t.Helper()
+
+	conv.expect(fmt.Sprintf("%q", text), func(output string) (int, bool) {
+		idx := strings.Index(output, text)
+		if idx == -1 {
+			return 0, false
+		}
+
+		return idx + len(text), true
+	})
+}
+
+// ExpectChoice waits for a choice with the given display to be listed after
+// the text expected so far, and returns its number.
+func (conv *Conversation) ExpectChoice(display string) int {
+	conv.t.Helper()
+
+	choice := regexp.MustCompile(`(?m)^(\d+): ` + regexp.QuoteMeta(display) + `$`)
+
+	var num int
+	conv.expect(fmt.Sprintf("choice %q", display), func(output string) (int, bool) {
+		match := choice.FindStringSubmatchIndex(output)
+		if match == nil {
+			return 0, false
+		}
+
+		num, _ = strconv.Atoi(output[match[2]:match[3]])
+
+		return match[1], true
+	})
+
+	return num
+}
+
+// Send enters the given line, as if the user had typed it and pressed enter.
+func (conv *Conversation) Send(line string) {
+	conv.input.write(line + "\n")
+}
+
+// SendChoice waits for a choice with the given display to be listed, and
+// selects it by sending its number.
+func (conv *Conversation) SendChoice(display string) {
+	conv.t.Helper()
+	conv.Send(strconv.Itoa(conv.ExpectChoice(display)))
+}
+
+// Close ends the input, as if the user had pressed Ctrl-D.
+func (conv *Conversation) Close() {
+	conv.input.close()
+}
+
+// Wait waits for the function passed to Start to return, returning its error.
+// Any output which was not expected is ignored.
+func (conv *Conversation) Wait() error {
+	conv.t.Helper()
+
+	err, finished := conv.wait(conv.Timeout)
+	if !finished {
+		conv.t.Fatalf("conversation did not finish within %s\n\n%s", conv.Timeout, conv.transcript())
+	}
+
+	return err
+}
+
+// expect waits for match to find what is expected in the output since the
+// cursor, returning the offset of the end of the match.
+func (conv *Conversation) expect(desc string, match func(string) (int, bool)) {
+	conv.t.Helper()
+
+	timeout := time.After(conv.Timeout)
+
+	for {
+		output, changed := conv.output.contents()
+
+		if end, found := match(output[conv.cursor:]); found {
+			conv.cursor += end
+			return
+		}
+
+		select {
+		case <-changed:
+		case <-conv.done:
+			// check what was printed before it finished
+			output, _ := conv.output.contents()
+			if end, found := match(output[conv.cursor:]); found {
+				conv.cursor += end
+				return
+			}
+
+			conv.t.Fatalf("conversation went off script: expected %s, but it finished (error: %v)\n\n%s", desc, conv.err, conv.offScript())
+			return
+		case <-timeout:
+			conv.t.Fatalf("conversation went off script: expected %s within %s\n\n%s", desc, conv.Timeout, conv.offScript())
+			return
+		}
+	}
+}
+
+// offScript describes the output since the last expected text.
+func (conv *Conversation) offScript() string {
+	output, _ := conv.output.contents()
+	return fmt.Sprintf("got instead:\n%s\n%s", indent(output[conv.cursor:]), conv.transcript())
+}
+
+// transcript returns the entire output, marking how far it was expected.
+func (conv *Conversation) transcript() string {
+	output, _ := conv.output.contents()
+	return fmt.Sprintf("conversation so far (expected up to ^):\n%s", indent(output[:conv.cursor]+"^"+output[conv.cursor:]))
+}
+
+func indent(text string) string {
+	if text == "" {
+		return "    (nothing)"
+	}
+
+	lines := strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = "    " + line
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// runner runs the code under test in the background.
+type runner struct {
+	done chan struct{}
+	err  error
+}
+
+func (r *runner) start(fn func() error) {
+	r.done = make(chan struct{})
+
+	go func() {
+		defer close(r.done)
+		r.err = fn()
+	}()
+}
+
+// wait waits for the code under test to finish, returning its error, or
+// false if it did not finish in time.
+func (r *runner) wait(timeout time.Duration) (error, bool) {
+	select {
+	case <-r.done:
+		return r.err, true
+	case <-time.After(timeout):
+		return nil, false
+	}
+}
+
+// buffer is an in-memory pipe which never blocks writers, and notifies any
+// waiting readers when written to.
+type buffer struct {
+	mutex   sync.Mutex
+	data    []byte
+	offset  int
+	closed  bool
+	changed chan struct{}
+}
+
+func newBuffer() *buffer {
+	return &buffer{changed: make(chan struct{})}
+}
+
+func (buf *buffer) Read(p []byte) (int, error) {
+	for {
+		buf.mutex.Lock()
+
+		if buf.offset < len(buf.data) {
+			n := copy(p, buf.data[buf.offset:])
+			buf.offset += n
+			buf.mutex.Unlock()
+			return n, nil
+		}
+
+		if buf.closed {
+			buf.mutex.Unlock()
+			return 0, io.EOF
+		}
+
+		changed := buf.changed
+		buf.mutex.Unlock()
+
+		<-changed
+	}
+}
+
+func (buf *buffer) Write(p []byte) (int, error) {
+	buf.write(string(p))
+	return len(p), nil
+}
+
+func (buf *buffer) write(data string) {
+	buf.mutex.Lock()
+	defer buf.mutex.Unlock()
+
+	buf.data = append(buf.data, data...)
+	buf.notify()
+}
+
+func (buf *buffer) close() {
+	buf.mutex.Lock()
+	defer buf.mutex.Unlock()
+
+	buf.closed = true
+	buf.notify()
+}
+
+// contents returns everything written so far, and a channel which is closed
+// when more is written.
+func (buf *buffer) contents() (string, <-chan struct{}) {
+	buf.mutex.Lock()
+	defer buf.mutex.Unlock()
+
+	return string(buf.data), buf.changed
+}
+
+// notify wakes up anyone waiting for a change; the mutex must be held.
+func (buf *buffer) notify() {
+	close(buf.changed)
+	buf.changed = make(chan struct{})
+}
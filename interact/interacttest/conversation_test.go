@@ -0,0 +1,141 @@
+package interacttest_test
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vito/go-interact/interact"
+	"github.com/vito/go-interact/interact/interacttest"
+)
+
+var _ = Describe("Conversation", func() {
+	var (
+		t    *fakeT
+		conv *interacttest.Conversation
+
+		username string
+		number   string
+	)
+
+	BeforeEach(func() {
+		t = &fakeT{}
+		conv = interacttest.New(t)
+		conv.Timeout = 100 * time.Millisecond
+
+		username = ""
+		number = "uno"
+
+		conv.Start(func() error {
+			err := conv.Interaction("Username").Resolve(interact.Required(&username))
+			if err != nil {
+				return err
+			}
+
+			return conv.Interaction("Number",
+				interact.Choice{Display: "Uno", Value: "uno"},
+				interact.Choice{Display: "Dos", Value: "dos"},
+			).Resolve(&number)
+		})
+	})
+
+	It("plays the part of the user", func() {
+		conv.Expect("Username: ")
+		conv.Send("alice")
+
+		Expect(conv.ExpectChoice("Dos")).To(Equal(2))
+		conv.Expect("Number (1): ")
+		conv.Send("2")
+
+		Expect(conv.Wait()).To(Succeed())
+		Expect(username).To(Equal("alice"))
+		Expect(number).To(Equal("dos"))
+
+		Expect(t.failure).To(BeEmpty())
+	})
+
+	It("selects choices by their display", func() {
+		conv.Send("alice")
+		conv.SendChoice("Dos")
+
+		Expect(conv.Wait()).To(Succeed())
+		Expect(number).To(Equal("dos"))
+	})
+
+	It("returns the error from the conversation", func() {
+		conv.Close()
+		Expect(conv.Wait()).To(Equal(io.EOF))
+	})
+
+	It("fails with what was printed instead when the expected text is not printed", func() {
+		conv.Send("alice")
+
+		Expect(t.run(func() { conv.Expect("Password: ") })).To(BeFalse())
+		Expect(t.failure).To(Equal(`conversation went off script: expected "Password: " within 100ms
+
+got instead:
+    Username: alice
+    1: Uno
+    2: Dos
+    Number (1): 
+conversation so far (expected up to ^):
+    ^Username: alice
+    1: Uno
+    2: Dos
+    Number (1): `))
+	})
+
+	It("fails without waiting when the conversation finishes early", func() {
+		conv.Expect("Username: ")
+		conv.Close()
+
+		Expect(t.run(func() { conv.ExpectChoice("Tres") })).To(BeFalse())
+		Expect(t.failure).To(Equal(`conversation went off script: expected choice "Tres", but it finished (error: EOF)
+
+got instead:
+    (nothing)
+conversation so far (expected up to ^):
+    Username: ^`))
+	})
+
+	It("fails when the conversation does not finish", func() {
+		Expect(t.run(func() { conv.Wait() })).To(BeFalse())
+		Expect(t.failure).To(HavePrefix("conversation did not finish within 100ms"))
+
+		conv.Close()
+	})
+})
+
+// fakeT records the failure, stopping the test function as testing.T does.
+type fakeT struct {
+	failure string
+}
+
+type fatal struct{}
+
+func (t *fakeT) Helper() {}
+
+func (t *fakeT) Fatalf(format string, args ...interface{}) {
+	t.failure = fmt.Sprintf(format, args...)
+	panic(fatal{})
+}
+
+// run calls fn, returning false if it failed.
+func (t *fakeT) run(fn func()) (passed bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			if _, ok := r.(fatal); !ok {
+				panic(r)
+			}
+
+			passed = false
+		}
+	}()
+
+	fn()
+
+	return true
+}
@@ -0,0 +1,315 @@
+package interacttest
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Screen is a small model of a VT100-style terminal screen, interpreting the
+// output written to it so that tests can look at what the user would see,
+// rather than the raw bytes.
+//
+// It understands cursor movement, erasing, and scrolling. Other escape
+// sequences, e.g. for colors, are ignored.
+type Screen struct {
+	mutex sync.Mutex
+
+	cells    [][]rune
+	row, col int
+
+	// wrap is true if the cursor is past the last column, and will wrap
+	// before the next character is written
+	wrap bool
+
+	// pending holds an incomplete escape sequence or UTF-8 character
+	pending []byte
+
+	changed chan struct{}
+}
+
+// NewScreen constructs an empty screen of the given size.
+func NewScreen(cols, rows int) *Screen {
+	screen := &Screen{changed: make(chan struct{})}
+
+	screen.cells = make([][]rune, rows)
+	for i := range screen.cells {
+		screen.cells[i] = blankLine(cols)
+	}
+
+	return screen
+}
+
+// Write interprets the given output.
+func (screen *Screen) Write(p []byte) (int, error) {
+	screen.mutex.Lock()
+	defer screen.mutex.Unlock()
+
+	data := append(screen.pending, p...)
+	screen.pending = nil
+
+	for len(data) > 0 {
+		n, complete := screen.interpret(data)
+		if !complete {
+			screen.pending = append([]byte(nil), data...)
+			break
+		}
+
+		data = data[n:]
+	}
+
+	close(screen.changed)
+	screen.changed = make(chan struct{})
+
+	return len(p), nil
+}
+
+// String returns the contents of the screen, one line per row, with trailing
+// spaces and blank lines removed.
+func (screen *Screen) String() string {
+	return strings.Join(screen.Lines(), "\n")
+}
+
+// Lines returns each row of the screen, with trailing spaces and blank lines
+// removed.
+func (screen *Screen) Lines() []string {
+	screen.mutex.Lock()
+	defer screen.mutex.Unlock()
+
+	lines := make([]string, len(screen.cells))
+	last := 0
+	for i, cells := range screen.cells {
+		lines[i] = strings.TrimRight(string(cells), " ")
+
+		if lines[i] != "" {
+			last = i + 1
+		}
+	}
+
+	return lines[:last]
+}
+
+// Cursor returns the row and column of the cursor, starting from 0.
+func (screen *Screen) Cursor() (int, int) {
+	screen.mutex.Lock()
+	defer screen.mutex.Unlock()
+
+	return screen.row, screen.col
+}
+
+// CursorLine returns the row the cursor is on, with trailing spaces removed.
+func (screen *Screen) CursorLine() string {
+	screen.mutex.Lock()
+	defer screen.mutex.Unlock()
+
+	return strings.TrimRight(string(screen.cells[screen.row]), " ")
+}
+
+// contents returns the screen's contents, and a channel which is closed when
+// more is written.
+func (screen *Screen) contents() (string, <-chan struct{}) {
+	screen.mutex.Lock()
+	changed := screen.changed
+	screen.mutex.Unlock()
+
+	return screen.String(), changed
+}
+
+// interpret handles the first character or sequence in data, returning how
+// many bytes it consumed, or false if it is incomplete.
+func (screen *Screen) interpret(data []byte) (int, bool) {
+	switch data[0] {
+	case '\x1b':
+		return screen.escape(data)
+	case '\r':
+		screen.col = 0
+		screen.wrap = false
+	case '\n':
+		screen.lineFeed()
+	case '\b':
+		if screen.col > 0 {
+			screen.col--
+		}
+
+		screen.wrap = false
+	case '\t':
+		screen.col = min((screen.col/8+1)*8, screen.cols()-1)
+	default:
+		if data[0] < ' ' || data[0] == 0x7f {
+			// ignore other control characters, e.g. bell
+			return 1, true
+		}
+
+		if !utf8.FullRune(data) {
+			return 0, false
+		}
+
+		r, n := utf8.DecodeRune(data)
+		screen.put(r)
+
+		return n, true
+	}
+
+	return 1, true
+}
+
+// escape handles an escape sequence at the start of data.
+func (screen *Screen) escape(data []byte) (int, bool) {
+	if len(data) < 2 {
+		return 0, false
+	}
+
+	if data[1] != '[' {
+		// ignore anything other than a control sequence, e.g. ESC 7
+		return 2, true
+	}
+
+	// parameters and intermediates, followed by a final byte
+	end := 2
+	for end < len(data) && (data[end] < 0x40 || data[end] > 0x7e) {
+		end++
+	}
+
+	if end == len(data) {
+		return 0, false
+	}
+
+	params := string(data[2:end])
+	if strings.HasPrefix(params, "?") {
+		// private modes, e.g. hiding the cursor or bracketed paste
+		return end + 1, true
+	}
+
+	screen.control(data[end], parseParams(params))
+
+	return end + 1, true
+}
+
+// control handles a control sequence with the given final byte.
+func (screen *Screen) control(final byte, params []int) {
+	param := func(i, def int) int {
+		if i < len(params) && params[i] != 0 {
+			return params[i]
+		}
+
+		return def
+	}
+
+	screen.wrap = false
+
+	switch final {
+	case 'A':
+		screen.row = max(screen.row-param(0, 1), 0)
+	case 'B':
+		screen.row = min(screen.row+param(0, 1), screen.rows()-1)
+	case 'C':
+		screen.col = min(screen.col+param(0, 1), screen.cols()-1)
+	case 'D':
+		screen.col = max(screen.col-param(0, 1), 0)
+	case 'E':
+		screen.row = min(screen.row+param(0, 1), screen.rows()-1)
+		screen.col = 0
+	case 'F':
+		screen.row = max(screen.row-param(0, 1), 0)
+		screen.col = 0
+	case 'G':
+		screen.col = min(param(0, 1)-1, screen.cols()-1)
+	case 'H', 'f':
+		screen.row = min(param(0, 1)-1, screen.rows()-1)
+		screen.col = min(param(1, 1)-1, screen.cols()-1)
+	case 'J':
+		screen.eraseDisplay(param(0, 0))
+	case 'K':
+		screen.eraseLine(param(0, 0))
+	}
+}
+
+func (screen *Screen) put(r rune) {
+	if screen.wrap {
+		screen.col = 0
+		screen.lineFeed()
+		screen.wrap = false
+	}
+
+	screen.cells[screen.row][screen.col] = r
+
+	if screen.col == screen.cols()-1 {
+		screen.wrap = true
+	} else {
+		screen.col++
+	}
+}
+
+func (screen *Screen) lineFeed() {
+	if screen.row < screen.rows()-1 {
+		screen.row++
+		return
+	}
+
+	// scroll up
+	copy(screen.cells, screen.cells[1:])
+	screen.cells[screen.rows()-1] = blankLine(screen.cols())
+}
+
+func (screen *Screen) eraseDisplay(mode int) {
+	switch mode {
+	case 0:
+		screen.eraseLine(0)
+		for row := screen.row + 1; row < screen.rows(); row++ {
+			screen.cells[row] = blankLine(screen.cols())
+		}
+	case 1:
+		screen.eraseLine(1)
+		for row := 0; row < screen.row; row++ {
+			screen.cells[row] = blankLine(screen.cols())
+		}
+	case 2, 3:
+		for row := range screen.cells {
+			screen.cells[row] = blankLine(screen.cols())
+		}
+	}
+}
+
+func (screen *Screen) eraseLine(mode int) {
+	from, to := 0, screen.cols()
+
+	switch mode {
+	case 0:
+		from = screen.col
+	case 1:
+		to = screen.col + 1
+	}
+
+	for col := from; col < to; col++ {
+		screen.cells[screen.row][col] = ' '
+	}
+}
+
+func (screen *Screen) rows() int {
+	return len(screen.cells)
+}
+
+func (screen *Screen) cols() int {
+	return len(screen.cells[0])
+}
+
+func blankLine(cols int) []rune {
+	return []rune(strings.Repeat(" ", cols))
+}
+
+// parseParams parses the semicolon-separated numeric parameters of a control
+// sequence. Missing or invalid parameters are 0.
+func parseParams(params string) []int {
+	if params == "" {
+		return nil
+	}
+
+	var parsed []int
+	for _, param := range strings.Split(params, ";") {
+		n, _ := strconv.Atoi(param)
+		parsed = append(parsed, n)
+	}
+
+	return parsed
+}
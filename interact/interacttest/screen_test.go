@@ -0,0 +1,56 @@
+package interacttest_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vito/go-interact/interact/interacttest"
+)
+
+var _ = Describe("Screen", func() {
+	var screen *interacttest.Screen
+
+	BeforeEach(func() {
+		screen = interacttest.NewScreen(10, 3)
+	})
+
+	write := func(output string) {
+		_, err := screen.Write([]byte(output))
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	It("writes text at the cursor", func() {
+		write("hello\r\nworld")
+		Expect(screen.Lines()).To(Equal([]string{"hello", "world"}))
+
+		row, col := screen.Cursor()
+		Expect(row).To(Equal(1))
+		Expect(col).To(Equal(5))
+	})
+
+	It("moves the cursor and erases", func() {
+		write("hello\r\nworld\x1b[A\x1b[3Dy\x1b[K\x1b[B\r\x1b[2CR")
+		Expect(screen.Lines()).To(Equal([]string{"hey", "woRld"}))
+		Expect(screen.CursorLine()).To(Equal("woRld"))
+
+		write("\x1b[1;1H\x1b[J")
+		Expect(screen.Lines()).To(BeEmpty())
+	})
+
+	It("wraps long lines and scrolls", func() {
+		write("0123456789abc\r\nline 2\r\nline 3")
+		Expect(screen.Lines()).To(Equal([]string{"abc", "line 2", "line 3"}))
+	})
+
+	It("handles sequences split across writes", func() {
+		write("caf\xc3")
+		write("\xa9\x1b[")
+		write("1Dé")
+		Expect(screen.String()).To(Equal("café"))
+	})
+
+	It("ignores colors and private modes", func() {
+		write("\x1b[?25l\x1b[7mhi\x1b[0m\x1b[?2004h")
+		Expect(screen.String()).To(Equal("hi"))
+	})
+})
@@ -0,0 +1,233 @@
+package interacttest
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/kr/pty"
+
+	"github.com/vito/go-interact/interact"
+)
+
+// Key is the sequence of bytes a terminal sends for a key press.
+type Key string
+
+// Keys which may be passed to Terminal.Press.
+const (
+	KeyEnter     Key = "\r"
+	KeyTab       Key = "\t"
+	KeyBackspace Key = "\x7f"
+	KeyEscape    Key = "\x1b"
+	KeyUp        Key = "\x1b[A"
+	KeyDown      Key = "\x1b[B"
+	KeyRight     Key = "\x1b[C"
+	KeyLeft      Key = "\x1b[D"
+	KeyHome      Key = "\x1b[H"
+	KeyEnd       Key = "\x1b[F"
+	KeyCtrlC     Key = "\x03"
+	KeyCtrlD     Key = "\x04"
+)
+
+// Terminal runs interactions against a pseudo-terminal, so that the terminal
+// code path is taken, with raw mode, line editing, and menus. Keys are sent as
+// a real terminal would send them, and the output is interpreted by a Screen.
+type Terminal struct {
+	// Timeout is how long to wait for expected screen contents, or for the
+	// interactions to finish.
+	Timeout time.Duration
+
+	t T
+
+	pty, tty *os.File
+
+	screen *Screen
+
+	runner
+}
+
+// NewTerminal opens an 80x24 pseudo-terminal, which is closed when the test
+// finishes.
+func NewTerminal(t TB) *Terminal {
+	return NewTerminalSize(t, 80, 24)
+}
+
+// NewTerminalSize opens a pseudo-terminal of the given size, which is closed
+// when the test finishes.
+func NewTerminalSize(t TB, cols, rows int) *Terminal {
+	t.Helper()
+
+	aPty, tty, err := pty.Open()
+	if err != nil {
+		t.Fatalf("failed to open pty: %s", err)
+	}
+
+	t.Cleanup(func() {
+		aPty.Close()
+		tty.Close()
+	})
+
+	err = pty.Setsize(tty, &pty.Winsize{Rows: uint16(rows), Cols: uint16(cols)})
+	if err != nil {
+		t.Fatalf("failed to set pty size: %s", err)
+	}
+
+	screen := NewScreen(cols, rows)
+
+	go io.Copy(screen, aPty)
+
+	return &Terminal{
+		Timeout: DefaultTimeout,
+
+		t: t,
+
+		pty: aPty,
+		tty: tty,
+
+		screen: screen,
+	}
+}
+
+// TTY returns the terminal end of the pseudo-terminal, to be used as the
+// Input and Output of an interaction.
+func (term *Terminal) TTY() *os.File {
+	return term.tty
+}
+
+// Interaction constructs an interaction which uses the terminal for its input
+// and output.
+func (term *Terminal) Interaction(prompt string, choices ...interact.Choice) interact.Interaction {
+	interaction := interact.NewInteraction(prompt, choices...)
+	interaction.Input = term.tty
+	interaction.Output = term.tty
+	return interaction
+}
+
+// Screen returns the screen showing the terminal's output.
+func (term *Terminal) Screen() *Screen {
+	return term.screen
+}
+
+// Start calls fn in the background, which should ask questions using the
+// terminal. Its error is returned by Wait.
+func (term *Terminal) Start(fn func() error) {
+	term.start(fn)
+}
+
+// Type sends the given text, as if the user had typed it.
+func (term *Terminal) Type(text string) {
+	term.t.Helper()
+	term.send(text)
+}
+
+// Press sends the given keys, in order.
+func (term *Terminal) Press(keys ...Key) {
+	term.t.Helper()
+
+	for _, key := range keys {
+		term.send(string(key))
+	}
+}
+
+// Paste sends the given text as a bracketed paste, as terminals do when the
+// user pastes text while the application has enabled bracketed paste mode.
+func (term *Terminal) Paste(text string) {
+	term.t.Helper()
+	term.send("\x1b[200~" + text + "\x1b[201~")
+}
+
+// ExpectScreen waits for the screen to contain the given text.
+func (term *Terminal) ExpectScreen(text string) {
+	term.t.Helper()
+
+	term.expect(fmt.Sprintf("screen to contain %q", text), func(screen string) bool {
+		return strings.Contains(screen, text)
+	})
+}
+
+// ExpectCursorLine waits for the line the cursor is on to be the given text,
+// ignoring trailing spaces.
+func (term *Terminal) ExpectCursorLine(line string) {
+	term.t.Helper()
+
+	term.expect(fmt.Sprintf("cursor line to be %q", line), func(string) bool {
+		return term.screen.CursorLine() == line
+	})
+}
+
+// Wait waits for the function passed to Start to return, returning its error.
+func (term *Terminal) Wait() error {
+	term.t.Helper()
+
+	err, finished := term.wait(term.Timeout)
+	if !finished {
+		term.t.Fatalf("terminal interaction did not finish within %s\n\n%s", term.Timeout, term.dump())
+	}
+
+	return err
+}
+
+func (term *Terminal) send(data string) {
+	term.t.Helper()
+
+	_, err := term.pty.Write([]byte(data))
+	if err != nil {
+		term.t.Fatalf("failed to write to pty: %s", err)
+	}
+}
+
+// expect waits for check to return true for the screen's contents.
+func (term *Terminal) expect(desc string, check func(string) bool) {
+	term.t.Helper()
+
+	timeout := time.After(term.Timeout)
+
+	for {
+		screen, changed := term.screen.contents()
+		if check(screen) {
+			return
+		}
+
+		select {
+		case <-changed:
+		case <-term.done:
+			// give the final output a moment to be read from the pty
+			select {
+			case <-changed:
+				continue
+			case <-time.After(100 * time.Millisecond):
+			}
+
+			if check(term.screen.String()) {
+				return
+			}
+
+			term.t.Fatalf("terminal went off script: expected %s, but it finished (error: %v)\n\n%s", desc, term.err, term.dump())
+			return
+		case <-timeout:
+			term.t.Fatalf("terminal went off script: expected %s within %s\n\n%s", desc, term.Timeout, term.dump())
+			return
+		}
+	}
+}
+
+// dump shows the screen's contents, marking the cursor.
+func (term *Terminal) dump() string {
+	lines := term.screen.Lines()
+	row, col := term.screen.Cursor()
+
+	for len(lines) <= row {
+		lines = append(lines, "")
+	}
+
+	line := []rune(lines[row])
+	for len(line) < col {
+		line = append(line, ' ')
+	}
+
+	lines[row] = string(line[:col]) + "█" + string(line[col:])
+
+	return fmt.Sprintf("screen (cursor at █):\n%s", indent(strings.Join(lines, "\n")))
+}
@@ -0,0 +1,85 @@
+package interacttest_test
+
+import (
+	"io"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vito/go-interact/interact"
+	"github.com/vito/go-interact/interact/interacttest"
+)
+
+var _ = Describe("Terminal", func() {
+	var term *interacttest.Terminal
+
+	BeforeEach(func() {
+		term = interacttest.NewTerminal(GinkgoT())
+	})
+
+	It("allows the line to be edited", func() {
+		var username string
+		term.Start(func() error {
+			return term.Interaction("Username").Resolve(interact.Required(&username))
+		})
+
+		term.ExpectCursorLine("Username:")
+
+		term.Type("alcie")
+		term.Press(interacttest.KeyLeft, interacttest.KeyLeft, interacttest.KeyBackspace, interacttest.KeyRight)
+		term.Type("c")
+		term.ExpectCursorLine("Username: alice")
+
+		term.Press(interacttest.KeyEnter)
+		Expect(term.Wait()).To(Succeed())
+		Expect(username).To(Equal("alice"))
+	})
+
+	It("selects from menus with the arrow keys", func() {
+		var number string
+		term.Start(func() error {
+			return term.Interaction("Choose a number",
+				interact.Choice{Display: "Uno", Value: "uno"},
+				interact.Choice{Display: "Dos", Value: "dos"},
+				interact.Choice{Display: "Tres", Value: "tres"},
+			).Resolve(&number)
+		})
+
+		term.ExpectScreen("> Uno")
+
+		term.Press(interacttest.KeyDown, interacttest.KeyDown, interacttest.KeyUp)
+		term.ExpectScreen("> Dos")
+
+		term.Press(interacttest.KeyEnter)
+		Expect(term.Wait()).To(Succeed())
+		Expect(number).To(Equal("dos"))
+
+		term.ExpectScreen("Choose a number: Dos")
+		Expect(term.Screen().String()).To(Equal("Choose a number: Dos"))
+	})
+
+	It("returns EOF when Ctrl-C is pressed", func() {
+		var username string
+		term.Start(func() error {
+			return term.Interaction("Username").Resolve(&username)
+		})
+
+		term.ExpectCursorLine("Username ():")
+
+		term.Press(interacttest.KeyCtrlC)
+		Expect(term.Wait()).To(Equal(io.EOF))
+	})
+
+	It("accepts pasted text", func() {
+		var username string
+		term.Start(func() error {
+			return term.Interaction("Username").Resolve(&username)
+		})
+
+		term.ExpectCursorLine("Username ():")
+
+		term.Paste("alice\r")
+		Expect(term.Wait()).To(Succeed())
+		Expect(username).To(Equal("alice"))
+	})
+})
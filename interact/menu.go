@@ -0,0 +1,602 @@
+package interact
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// selector is implemented by users that can pick from a list of options
+// interactively, rather than by entering numbers.
+type selector interface {
+	Select(prompt string, options []string, initial int) (int, error)
+	SelectMultiple(prompt string, options []string, selected []int) ([]int, error)
+}
+
+type menuKey int
+
+const (
+	menuKeyNone menuKey = iota
+	menuKeyRune
+	menuKeyUp
+	menuKeyDown
+	menuKeyHome
+	menuKeyEnd
+	menuKeyPageUp
+	menuKeyPageDown
+	menuKeyBackspace
+	menuKeyEscape
+	menuKeyEnter
+	menuKeyInterrupt
+)
+
+type menuInput struct {
+	key  menuKey
+	rune rune
+}
+
+const (
+	hideCursor = "\x1b[?25l"
+	showCursor = "\x1b[?25h"
+
+	startHighlight = "\x1b[7m"
+	startFaint     = "\x1b[2m"
+	startMatch     = "\x1b[1;4m"
+	endMatch       = "\x1b[22;24m"
+	resetStyle     = "\x1b[0m"
+)
+
+// menu renders a list of options on a terminal in raw mode, allowing the user
+// to move a cursor through them with the arrow keys (or j/k) and select with
+// enter.
+//
+// Typing / starts filtering the options, after which any text typed is
+// fuzzy-matched against them. Escape stops filtering, or goes back if going
+// back is allowed and the menu is not filtering.
+//
+// If there are more options than fit on the terminal, only a window of them
+// around the cursor is shown.
+type menu struct {
+	prompt   string
+	options  []string
+	multiple bool
+	selected map[int]bool
+
+	// back is true if escape should go back, when not filtering
+	back bool
+
+	filtering bool
+	query     []rune
+	matches   []menuMatch
+
+	// cursor is the position of the cursor within matches
+	cursor int
+	offset int
+	size   int
+	width  int
+
+	// drawn is the number of lines drawn by the last render
+	drawn int
+}
+
+// menuMatch is an option matching the current query, along with the
+// positions of the runes that matched.
+type menuMatch struct {
+	option    int
+	positions []int
+}
+
+func newMenu(prompt string, options []string, width int, height int) *menu {
+	size := len(options)
+	if height > 2 && size > height-2 {
+		// leave room for the prompt and the line the cursor rests on
+		size = height - 2
+	}
+
+	m := &menu{
+		prompt:   prompt,
+		options:  options,
+		selected: map[int]bool{},
+		size:     size,
+		width:    width,
+	}
+
+	m.filter()
+
+	return m
+}
+
+// current returns the option under the cursor, if any options match.
+func (m *menu) current() (int, bool) {
+	if len(m.matches) == 0 {
+		return 0, false
+	}
+
+	return m.matches[m.cursor].option, true
+}
+
+// moveTo places the cursor on the given option, if it matches.
+func (m *menu) moveTo(option int) {
+	for i, match := range m.matches {
+		if match.option == option {
+			m.cursor = i
+			return
+		}
+	}
+}
+
+func (m *menu) run(input io.Reader, output io.Writer) error {
+	_, err := fmt.Fprint(output, hideCursor)
+	if err != nil {
+		return err
+	}
+
+	defer fmt.Fprint(output, showCursor)
+
+	err = m.render(output)
+	if err != nil {
+		return err
+	}
+
+	reader, ok := input.(contextReader)
+	if !ok {
+		reader = newContextReader(context.Background(), input)
+	}
+
+	buf := make([]byte, 256)
+
+	// partial is the start of a sequence split across reads
+	var partial []byte
+
+	for {
+		var inputs []menuInput
+
+		n, err := readMenuInput(reader, buf, partial)
+		if err == errEscapeTimeout {
+			// nothing followed the escape, so it was the escape key itself
+			inputs, partial = []menuInput{{key: menuKeyEscape}}, nil
+		} else if err != nil {
+			m.clear(output)
+			return err
+		} else {
+			inputs, partial = parseMenuInput(append(partial, buf[:n]...))
+		}
+
+		for _, in := range inputs {
+			switch in.key {
+			case menuKeyEnter:
+				if _, ok := m.current(); ok || m.multiple {
+					return m.finish(output)
+				}
+			case menuKeyInterrupt:
+				m.clear(output)
+				return io.EOF
+			case menuKeyEscape:
+				if m.back && !m.filtering {
+					m.clear(output)
+					return errBack
+				}
+
+				m.handle(in)
+			default:
+				m.handle(in)
+			}
+		}
+
+		err = m.render(output)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (m *menu) handle(in menuInput) {
+	switch in.key {
+	case menuKeyUp:
+		m.cursor--
+	case menuKeyDown:
+		m.cursor++
+	case menuKeyHome:
+		m.cursor = 0
+	case menuKeyEnd:
+		m.cursor = len(m.matches) - 1
+	case menuKeyPageUp:
+		m.cursor -= m.size
+	case menuKeyPageDown:
+		m.cursor += m.size
+	case menuKeyEscape:
+		m.stopFiltering()
+	case menuKeyBackspace:
+		if !m.filtering {
+			break
+		}
+
+		if len(m.query) == 0 {
+			m.stopFiltering()
+		} else {
+			m.query = m.query[:len(m.query)-1]
+			m.filter()
+		}
+	case menuKeyRune:
+		if m.filtering {
+			m.query = append(m.query, in.rune)
+			m.filter()
+			break
+		}
+
+		switch in.rune {
+		case 'k':
+			m.cursor--
+		case 'j':
+			m.cursor++
+		case ' ':
+			if option, ok := m.current(); ok && m.multiple {
+				m.selected[option] = !m.selected[option]
+			}
+		case '/':
+			m.filtering = true
+		}
+	}
+
+	if m.cursor >= len(m.matches) {
+		m.cursor = len(m.matches) - 1
+	}
+
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+}
+
+func (m *menu) stopFiltering() {
+	m.filtering = false
+	m.query = nil
+	m.filter()
+}
+
+// filter updates the matches for the current query, keeping the cursor on
+// the same option if it still matches.
+func (m *menu) filter() {
+	option, hadCurrent := m.current()
+
+	m.matches = nil
+	for i, display := range m.options {
+		positions, ok := fuzzyMatch(display, m.query)
+		if ok {
+			m.matches = append(m.matches, menuMatch{
+				option:    i,
+				positions: positions,
+			})
+		}
+	}
+
+	m.cursor = 0
+	m.offset = 0
+
+	if hadCurrent {
+		m.moveTo(option)
+	}
+}
+
+// chosen returns the selected options, in order.
+func (m *menu) chosen() []int {
+	idxs := []int{}
+	for i := range m.options {
+		if m.selected[i] {
+			idxs = append(idxs, i)
+		}
+	}
+
+	return idxs
+}
+
+func (m *menu) lines() []string {
+	// the header is kept to one row like the options, since a wrapped line
+	// would throw off how far render moves back up to redraw
+	header := m.prompt + ":"
+	if m.filtering {
+		header = truncate(header+" /"+string(m.query), m.width-1)
+	} else {
+		hints := []string{}
+		if m.multiple {
+			hints = append(hints, "space to toggle")
+		}
+
+		hints = append(hints, "/ to filter")
+
+		if m.multiple {
+			hints = append(hints, "enter to confirm")
+		}
+
+		header = truncate(header, m.width-1)
+
+		hint := "(" + strings.Join(hints, ", ") + ")"
+		room := m.width - 1 - utf8.RuneCountInString(header) - 1
+		if m.width <= 0 {
+			header += " " + startFaint + hint + resetStyle
+		} else if room > 0 {
+			header += " " + startFaint + truncate(hint, room) + resetStyle
+		}
+	}
+
+	lines := []string{header}
+
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	} else if m.cursor >= m.offset+m.size {
+		m.offset = m.cursor - m.size + 1
+	}
+
+	for i := m.offset; i < m.offset+m.size && i < len(m.matches); i++ {
+		match := m.matches[i]
+
+		prefix := "  "
+		if i == m.cursor {
+			prefix = "> "
+		}
+
+		if m.multiple {
+			if m.selected[match.option] {
+				prefix += "[x] "
+			} else {
+				prefix += "[ ] "
+			}
+		}
+
+		display := truncate(m.options[match.option], m.width-len(prefix)-1)
+
+		line := prefix + highlightMatches(display, match.positions)
+		if i == m.cursor {
+			line = startHighlight + line + resetStyle
+		}
+
+		lines = append(lines, line)
+	}
+
+	if len(m.matches) == 0 {
+		lines = append(lines, startFaint+"  (no matches)"+resetStyle)
+	}
+
+	return lines
+}
+
+func (m *menu) render(output io.Writer) error {
+	buf := new(bytes.Buffer)
+
+	if m.drawn > 0 {
+		fmt.Fprintf(buf, "\x1b[%dA", m.drawn)
+	}
+
+	lines := m.lines()
+	for _, line := range lines {
+		fmt.Fprintf(buf, "\r\x1b[K%s\r\n", line)
+	}
+
+	if len(lines) < m.drawn {
+		buf.WriteString("\x1b[J")
+	}
+
+	m.drawn = len(lines)
+
+	_, err := output.Write(buf.Bytes())
+	return err
+}
+
+// clear erases the menu, leaving the cursor where it started.
+func (m *menu) clear(output io.Writer) error {
+	if m.drawn == 0 {
+		return nil
+	}
+
+	_, err := fmt.Fprintf(output, "\x1b[%dA\r\x1b[J", m.drawn)
+	m.drawn = 0
+	return err
+}
+
+// finish replaces the menu with a single line showing what was chosen.
+func (m *menu) finish(output io.Writer) error {
+	err := m.clear(output)
+	if err != nil {
+		return err
+	}
+
+	var answer string
+	if m.multiple {
+		displays := []string{}
+		for _, idx := range m.chosen() {
+			displays = append(displays, m.options[idx])
+		}
+
+		answer = strings.Join(displays, ", ")
+	} else {
+		option, _ := m.current()
+		answer = m.options[option]
+	}
+
+	_, err = fmt.Fprintf(output, "%s: %s\r\n", m.prompt, answer)
+	return err
+}
+
+// fuzzyMatch checks whether the query's runes all appear in str in order,
+// ignoring case, returning the positions of the matching runes in str.
+func fuzzyMatch(str string, query []rune) ([]int, bool) {
+	positions := []int{}
+
+	q := 0
+	for i, r := range []rune(str) {
+		if q == len(query) {
+			break
+		}
+
+		if unicode.ToLower(r) == unicode.ToLower(query[q]) {
+			positions = append(positions, i)
+			q++
+		}
+	}
+
+	return positions, q == len(query)
+}
+
+// highlightMatches emphasizes the runes of str at the given positions.
+func highlightMatches(str string, positions []int) string {
+	if len(positions) == 0 {
+		return str
+	}
+
+	matched := map[int]bool{}
+	for _, pos := range positions {
+		matched[pos] = true
+	}
+
+	buf := new(strings.Builder)
+	for i, r := range []rune(str) {
+		if matched[i] {
+			buf.WriteString(startMatch)
+			buf.WriteRune(r)
+			buf.WriteString(endMatch)
+		} else {
+			buf.WriteRune(r)
+		}
+	}
+
+	return buf.String()
+}
+
+// escapeDelay is how long to wait for the rest of a sequence after an escape,
+// before deciding that it was the escape key.
+const escapeDelay = 50 * time.Millisecond
+
+// errEscapeTimeout is returned by readMenuInput when nothing follows an
+// escape.
+var errEscapeTimeout = errors.New("nothing followed escape")
+
+// readMenuInput reads more input. If all there is so far is an escape, which
+// is either the escape key or the start of a sequence split across reads, it
+// only waits a moment for the rest of the sequence, returning
+// errEscapeTimeout if nothing arrives.
+func readMenuInput(reader contextReader, buf []byte, partial []byte) (int, error) {
+	if string(partial) != "\x1b" {
+		return reader.Read(buf)
+	}
+
+	ctx, cancel := context.WithTimeout(reader.ctx, escapeDelay)
+	defer cancel()
+
+	// a read that is given up on is kept, so nothing is lost
+	n, err := newContextReader(ctx, reader.reader).Read(buf)
+	if err != nil && reader.ctx.Err() == nil && ctx.Err() != nil {
+		return 0, errEscapeTimeout
+	}
+
+	return n, err
+}
+
+// parseMenuInput translates raw terminal input into input understood by the
+// menu, ignoring anything else. An incomplete rune or sequence at the end is
+// returned separately, to be completed by the next read.
+func parseMenuInput(b []byte) ([]menuInput, []byte) {
+	var inputs []menuInput
+
+	for len(b) > 0 {
+		if b[0] != '\x1b' {
+			if !utf8.FullRune(b) {
+				break
+			}
+
+			r, size := utf8.DecodeRune(b)
+			inputs = append(inputs, menuInputFor(r))
+			b = b[size:]
+			continue
+		}
+
+		if len(b) == 1 {
+			break
+		}
+
+		if b[1] != '[' && b[1] != 'O' {
+			inputs = append(inputs, menuInput{key: menuKeyEscape})
+			b = b[1:]
+			continue
+		}
+
+		end := 2
+		for end < len(b) && (b[end] < 0x40 || b[end] > 0x7e) {
+			end++
+		}
+
+		if end == len(b) {
+			break
+		}
+
+		inputs = append(inputs, menuInput{key: menuKeyForSequence(string(b[2:end]), b[end])})
+		b = b[end+1:]
+	}
+
+	return inputs, append([]byte(nil), b...)
+}
+
+func menuInputFor(r rune) menuInput {
+	switch r {
+	case 16: // ^P
+		return menuInput{key: menuKeyUp}
+	case 14: // ^N
+		return menuInput{key: menuKeyDown}
+	case 127, 8: // ^H
+		return menuInput{key: menuKeyBackspace}
+	case '\r', '\n':
+		return menuInput{key: menuKeyEnter}
+	case 3, 4: // ^C, ^D
+		return menuInput{key: menuKeyInterrupt}
+	}
+
+	if unicode.IsPrint(r) {
+		return menuInput{key: menuKeyRune, rune: r}
+	}
+
+	return menuInput{key: menuKeyNone}
+}
+
+func menuKeyForSequence(params string, final byte) menuKey {
+	switch final {
+	case 'A':
+		return menuKeyUp
+	case 'B':
+		return menuKeyDown
+	case 'H':
+		return menuKeyHome
+	case 'F':
+		return menuKeyEnd
+	case '~':
+		switch params {
+		case "1", "7":
+			return menuKeyHome
+		case "4", "8":
+			return menuKeyEnd
+		case "5":
+			return menuKeyPageUp
+		case "6":
+			return menuKeyPageDown
+		}
+	}
+
+	return menuKeyNone
+}
+
+// truncate shortens str to at most max runes, if max is positive.
+func truncate(str string, max int) string {
+	runes := []rune(str)
+	if max <= 0 || len(runes) <= max {
+		return str
+	}
+
+	if max == 1 {
+		return "…"
+	}
+
+	return string(runes[:max-1]) + "…"
+}
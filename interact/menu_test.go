@@ -0,0 +1,357 @@
+package interact_test
+
+import (
+	"io"
+	"os"
+
+	"github.com/kr/pty"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+
+	"github.com/vito/go-interact/interact"
+	"github.com/vito/go-interact/interact/interacttest"
+)
+
+var _ = Describe("Selecting from a menu on a terminal", func() {
+	var (
+		aPty, tty *os.File
+		output    *gbytes.Buffer
+
+		interaction interact.Interaction
+	)
+
+	BeforeEach(func() {
+		var err error
+		aPty, tty, err = pty.Open()
+		Expect(err).NotTo(HaveOccurred())
+
+		DeferCleanup(aPty.Close)
+		DeferCleanup(tty.Close)
+
+		output = gbytes.NewBuffer()
+		go io.Copy(output, aPty)
+
+		interaction = interact.NewInteraction(
+			"Choose a number",
+			interact.Choice{Display: "Uno", Value: "uno"},
+			interact.Choice{Display: "Dos", Value: "dos"},
+			interact.Choice{Display: "Tres", Value: "tres"},
+		)
+		interaction.Input = tty
+		interaction.Output = tty
+	})
+
+	resolve := func(dst interface{}) <-chan error {
+		errs := make(chan error, 1)
+		go func() {
+			errs <- interaction.Resolve(dst)
+		}()
+
+		Eventually(output).Should(gbytes.Say(`Choose a number:`))
+
+		return errs
+	}
+
+	send := func(keys string) {
+		_, err := aPty.Write([]byte(keys))
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	It("does not print the numbered list", func() {
+		var chosen string
+		errs := resolve(&chosen)
+
+		send("\r")
+		Eventually(errs).Should(Receive(BeNil()))
+
+		Expect(string(output.Contents())).NotTo(ContainSubstring("1: Uno"))
+	})
+
+	It("highlights the default choice and selects it on enter", func() {
+		chosen := "dos"
+		errs := resolve(&chosen)
+
+		Eventually(output).Should(gbytes.Say(`\x1b\[7m> Dos`))
+
+		send("\r")
+		Eventually(errs).Should(Receive(BeNil()))
+		Expect(chosen).To(Equal("dos"))
+
+		Eventually(output).Should(gbytes.Say(`Choose a number: Dos\r\n`))
+	})
+
+	It("starts at the first choice when there is no default", func() {
+		var chosen string
+		errs := resolve(&chosen)
+
+		send("\r")
+		Eventually(errs).Should(Receive(BeNil()))
+		Expect(chosen).To(Equal("uno"))
+	})
+
+	It("moves the cursor with the arrow keys", func() {
+		var chosen string
+		errs := resolve(&chosen)
+
+		send("\x1b[B")
+		Eventually(output).Should(gbytes.Say(`\x1b\[7m> Dos`))
+
+		send("\x1b[B")
+		Eventually(output).Should(gbytes.Say(`\x1b\[7m> Tres`))
+
+		send("\x1b[A\r")
+		Eventually(errs).Should(Receive(BeNil()))
+		Expect(chosen).To(Equal("dos"))
+	})
+
+	It("moves the cursor with j and k", func() {
+		var chosen string
+		errs := resolve(&chosen)
+
+		send("jjjk\r")
+		Eventually(errs).Should(Receive(BeNil()))
+		Expect(chosen).To(Equal("dos"))
+	})
+
+	It("returns EOF when Ctrl-C is pressed", func() {
+		chosen := "dos"
+		errs := resolve(&chosen)
+
+		send("\x03")
+		Eventually(errs).Should(Receive(Equal(io.EOF)))
+		Expect(chosen).To(Equal("dos"))
+	})
+
+	Context("when there are more choices than fit on the terminal", func() {
+		BeforeEach(func() {
+			Expect(pty.Setsize(tty, &pty.Winsize{Rows: 4, Cols: 80})).To(Succeed())
+		})
+
+		It("only shows the choices around the cursor", func() {
+			var chosen string
+			errs := resolve(&chosen)
+
+			Eventually(output).Should(gbytes.Say(`Uno`))
+			Eventually(output).Should(gbytes.Say(`Dos`))
+			Consistently(output).ShouldNot(gbytes.Say(`Tres`))
+
+			send("jj")
+			Eventually(output).Should(gbytes.Say(`\x1b\[7m> Tres`))
+
+			send("\r")
+			Eventually(errs).Should(Receive(BeNil()))
+			Expect(chosen).To(Equal("tres"))
+		})
+	})
+
+	Context("when filtering the choices", func() {
+		It("shows how to start filtering, even when every choice fits", func() {
+			var chosen string
+			errs := resolve(&chosen)
+
+			Eventually(output).Should(gbytes.Say(`/ to filter`))
+
+			send("\r")
+			Eventually(errs).Should(Receive(BeNil()))
+		})
+
+		It("selects from the choices matching the query", func() {
+			var chosen string
+			errs := resolve(&chosen)
+
+			send("/")
+			Eventually(output).Should(gbytes.Say(`Choose a number: /`))
+
+			send("os")
+			Eventually(output).Should(gbytes.Say(`Choose a number: /os`))
+			Eventually(output).Should(gbytes.Say(`\x1b\[7m> D\x1b\[1;4mo\x1b\[22;24m\x1b\[1;4ms\x1b\[22;24m`))
+
+			send("\r")
+			Eventually(errs).Should(Receive(BeNil()))
+			Expect(chosen).To(Equal("dos"))
+		})
+
+		It("matches fuzzily and ignores case", func() {
+			var chosen string
+			errs := resolve(&chosen)
+
+			send("/TS\r")
+			Eventually(errs).Should(Receive(BeNil()))
+			Expect(chosen).To(Equal("tres"))
+		})
+
+		It("treats j, k, and space as part of the query", func() {
+			interaction.Choices = append(interaction.Choices, interact.Choice{Display: "Jack Kerouac", Value: "jk"})
+
+			var chosen string
+			errs := resolve(&chosen)
+
+			send("/j k\r")
+			Eventually(errs).Should(Receive(BeNil()))
+			Expect(chosen).To(Equal("jk"))
+		})
+
+		It("moves the cursor through the matching choices", func() {
+			var chosen string
+			errs := resolve(&chosen)
+
+			send("/s")
+			Eventually(output).Should(gbytes.Say(`\x1b\[7m> Do`))
+
+			send("\x1b[B\r")
+			Eventually(errs).Should(Receive(BeNil()))
+			Expect(chosen).To(Equal("tres"))
+		})
+
+		It("does not select anything when nothing matches", func() {
+			var chosen string
+			errs := resolve(&chosen)
+
+			send("/xyz\r")
+			Eventually(output).Should(gbytes.Say(`no matches`))
+			Consistently(errs).ShouldNot(Receive())
+
+			send("\x7f\x7f\x7f\r")
+			Eventually(errs).Should(Receive(BeNil()))
+			Expect(chosen).To(Equal("uno"))
+		})
+
+		It("stops filtering when escape is pressed, keeping the cursor where it was", func() {
+			var chosen string
+			errs := resolve(&chosen)
+
+			send("/tr")
+			Eventually(output).Should(gbytes.Say(`\x1b\[7m> `))
+
+			send("\x1b")
+			Eventually(output).Should(gbytes.Say(`Uno`))
+
+			send("k\r")
+			Eventually(errs).Should(Receive(BeNil()))
+			Expect(chosen).To(Equal("dos"))
+		})
+	})
+
+	Context("when selecting multiple choices", func() {
+		It("toggles choices with space, starting with the current selection", func() {
+			chosen := []string{"tres"}
+			errs := resolve(&chosen)
+
+			Eventually(output).Should(gbytes.Say(`\x1b\[7m> \[x\] Tres`))
+
+			send("kk ")
+			Eventually(output).Should(gbytes.Say(`\x1b\[7m> \[x\] Uno`))
+
+			send("\r")
+			Eventually(errs).Should(Receive(BeNil()))
+			Expect(chosen).To(Equal([]string{"uno", "tres"}))
+
+			Eventually(output).Should(gbytes.Say(`Choose a number: Uno, Tres\r\n`))
+		})
+	})
+
+	Context("when part of a flow that allows going back", func() {
+		It("goes back to the previous question when escape is pressed", func() {
+			var first, second string
+
+			flow := interact.NewFlow(
+				interact.Step{Interaction: interaction, Destination: &first},
+				interact.Step{Interaction: interaction, Destination: &second},
+			)
+			flow.Input = tty
+			flow.Output = tty
+			flow.BackInput = "<"
+
+			errs := make(chan error, 1)
+			go func() {
+				errs <- flow.Resolve()
+			}()
+
+			send("j\r")
+			Eventually(output).Should(gbytes.Say(`Choose a number: Dos\r\n`))
+
+			send("\x1b")
+			Eventually(output).Should(gbytes.Say(`\x1b\[7m> Dos`))
+
+			send("j\r")
+			Eventually(output).Should(gbytes.Say(`Choose a number: Tres\r\n`))
+
+			send("\r")
+			Eventually(errs).Should(Receive(BeNil()))
+			Expect(first).To(Equal("tres"))
+			Expect(second).To(Equal("uno"))
+		})
+
+		It("does not go back when an arrow key arrives in pieces", func() {
+			var first, second string
+
+			flow := interact.NewFlow(
+				interact.Step{Interaction: interaction, Destination: &first},
+				interact.Step{Interaction: interaction, Destination: &second},
+			)
+			flow.Input = tty
+			flow.Output = tty
+			flow.BackInput = "<"
+
+			errs := make(chan error, 1)
+			go func() {
+				errs <- flow.Resolve()
+			}()
+
+			send("\r")
+			Eventually(output).Should(gbytes.Say(`Choose a number: Uno\r\n`))
+
+			send("\x1b")
+			send("[")
+			send("B")
+			Eventually(output).Should(gbytes.Say(`\x1b\[7m> Dos`))
+
+			send("\x1b[")
+			send("B")
+			Eventually(output).Should(gbytes.Say(`\x1b\[7m> Tres`))
+
+			send("\r")
+			Eventually(errs).Should(Receive(BeNil()))
+			Expect(first).To(Equal("uno"))
+			Expect(second).To(Equal("tres"))
+		})
+	})
+
+	Context("when the prompt is wider than the terminal", func() {
+		It("cuts the prompt short rather than wrapping it, so it is redrawn in place", func() {
+			term := interacttest.NewTerminalSize(GinkgoT(), 40, 24)
+
+			var chosen string
+			interaction := term.Interaction(
+				"Pick the region to deploy this into",
+				interact.Choice{Display: "Uno", Value: "uno"},
+				interact.Choice{Display: "Dos", Value: "dos"},
+				interact.Choice{Display: "Tres", Value: "tres"},
+			)
+
+			term.Start(func() error {
+				return interaction.Resolve(&chosen)
+			})
+
+			term.ExpectScreen("Pick the region to deploy this into: (…")
+
+			term.Press(interacttest.KeyDown)
+			term.ExpectScreen("> Dos")
+
+			term.Press(interacttest.KeyDown)
+			term.ExpectScreen("> Tres")
+
+			Expect(term.Screen().Lines()).To(Equal([]string{
+				"Pick the region to deploy this into: (…",
+				"  Uno",
+				"  Dos",
+				"> Tres",
+			}))
+
+			term.Press(interacttest.KeyEnter)
+			Expect(term.Wait()).To(Succeed())
+			Expect(chosen).To(Equal("tres"))
+		})
+	})
+})
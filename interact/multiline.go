@@ -0,0 +1,13 @@
+package interact
+
+// MultilineEnd is the line which ends a Multiline answer when the input is
+// not a terminal.
+const MultilineEnd = "."
+
+// Multiline is a string which may span several lines, such as a description
+// or a pasted certificate.
+//
+// On a terminal, Enter starts a new line, and Ctrl-D or Alt-Enter finishes
+// the answer. Otherwise, the answer ends at a line containing only
+// MultilineEnd, or at the end of the input.
+type Multiline string
@@ -0,0 +1,160 @@
+package interact_test
+
+import (
+	"io"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vito/go-interact/interact"
+	"github.com/vito/go-interact/interact/interacttest"
+)
+
+var _ = Describe("Resolving into multiline strings", func() {
+	Context("when the destination is empty", func() {
+		BeforeEach(func() {
+			destination = multilineDst("")
+		})
+
+		DescribeTable("Resolve", (Example).Run,
+			Entry("when lines are entered, ending with the end line", Example{
+				Prompt: "some prompt",
+
+				Input: "first line\n\nthird line\n.\n",
+
+				ExpectedAnswer: interact.Multiline("first line\n\nthird line"),
+				ExpectedOutput: "some prompt (): \nfirst line\n\nthird line\n",
+			}),
+
+			Entry("when lines are entered, followed by EOF", Example{
+				Prompt: "some prompt",
+
+				Input: "-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----\n",
+
+				ExpectedAnswer: interact.Multiline("-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----"),
+				ExpectedOutput: "some prompt (): \n-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----\n",
+			}),
+
+			Entry("when only the end line is entered", Example{
+				Prompt: "some prompt",
+
+				Input: ".\n",
+
+				ExpectedAnswer: interact.Multiline(""),
+				ExpectedOutput: "some prompt (): \n",
+			}),
+
+			Entry("when nothing is entered", Example{
+				Prompt: "some prompt",
+
+				Input: "",
+
+				ExpectedAnswer: interact.Multiline(""),
+				ExpectedErr:    io.EOF,
+				ExpectedOutput: "some prompt (): \n",
+			}),
+		)
+
+		Context("when required", func() {
+			BeforeEach(func() {
+				destination = interact.Required(destination)
+			})
+
+			DescribeTable("Resolve", (Example).Run,
+				Entry("when only the end line is entered, followed by lines", Example{
+					Prompt: "some prompt",
+
+					Input: ".\nsome text\n.\n",
+
+					ExpectedAnswer: interact.Multiline("some text"),
+					ExpectedOutput: "some prompt: \nsome prompt: \nsome text\n",
+				}),
+			)
+		})
+	})
+
+	Context("when the destination is not empty", func() {
+		BeforeEach(func() {
+			destination = multilineDst("first line\nsecond line")
+		})
+
+		DescribeTable("Resolve", (Example).Run,
+			Entry("when lines are entered", Example{
+				Prompt: "some prompt",
+
+				Input: "new text\n.\n",
+
+				ExpectedAnswer: interact.Multiline("new text"),
+				ExpectedOutput: "some prompt (first line …): \nnew text\n",
+			}),
+
+			Entry("when only the end line is entered", Example{
+				Prompt: "some prompt",
+
+				Input: ".\n",
+
+				ExpectedAnswer: interact.Multiline("first line\nsecond line"),
+				ExpectedOutput: "some prompt (first line …): \n",
+			}),
+		)
+	})
+
+	Context("on a terminal", func() {
+		var (
+			term *interacttest.Terminal
+			text interact.Multiline
+		)
+
+		BeforeEach(func() {
+			term = interacttest.NewTerminal(GinkgoT())
+
+			text = ""
+
+			interaction := term.Interaction("Description")
+			term.Start(func() error {
+				return interaction.Resolve(interact.Required(&text))
+			})
+
+			term.ExpectScreen("Description: (Ctrl-D to finish)")
+		})
+
+		It("starts a new line on Enter, and finishes on Ctrl-D", func() {
+			term.Type("first line")
+			term.Press(interacttest.KeyEnter)
+			term.Press(interacttest.KeyEnter)
+			term.Type("third line")
+			term.Press(interacttest.KeyEnter)
+			term.ExpectScreen("third line")
+
+			term.Press(interacttest.KeyCtrlD)
+			Expect(term.Wait()).To(Succeed())
+			Expect(text).To(Equal(interact.Multiline("first line\n\nthird line")))
+		})
+
+		It("finishes on Alt-Enter, keeping the current line", func() {
+			term.Type("only line")
+			term.Press(interacttest.KeyEscape, interacttest.KeyEnter)
+			Expect(term.Wait()).To(Succeed())
+			Expect(text).To(Equal(interact.Multiline("only line")))
+		})
+
+		It("accepts pasted lines", func() {
+			term.Paste("-----BEGIN CERTIFICATE-----\rMIIB\r-----END CERTIFICATE-----\r")
+			term.ExpectScreen("-----END CERTIFICATE-----")
+
+			term.Press(interacttest.KeyCtrlD)
+			Expect(term.Wait()).To(Succeed())
+			Expect(text).To(Equal(interact.Multiline("-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----")))
+		})
+
+		It("gives up on Ctrl-C", func() {
+			term.Type("some text")
+			term.Press(interacttest.KeyCtrlC)
+			Expect(term.Wait()).To(Equal(io.EOF))
+		})
+	})
+})
+
+func multilineDst(dst interact.Multiline) *interact.Multiline {
+	return &dst
+}
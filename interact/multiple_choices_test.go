@@ -0,0 +1,187 @@
+package interact_test
+
+import (
+	"errors"
+	"io"
+
+	. "github.com/onsi/ginkgo/v2"
+
+	"github.com/vito/go-interact/interact"
+)
+
+var _ = Describe("Resolving multiple selections from a set of choices", func() {
+	BeforeEach(func() {
+		choices = []interact.Choice{
+			{Display: "Uno", Value: arbitrary{"uno"}},
+			{Display: "Dos", Value: arbitrary{"dos"}},
+			{Display: "Tres", Value: arbitrary{"tres"}},
+			{Display: "Cuatro", Value: arbitrary{"cuatro"}},
+		}
+	})
+
+	Context("when the destination is empty", func() {
+		BeforeEach(func() {
+			destination = arbsDst(nil)
+		})
+
+		DescribeTable("Resolve", (Example).Run,
+			Entry("when a single choice is entered", Example{
+				Prompt: "some prompt",
+
+				Input: "2\n",
+
+				ExpectedAnswer: []arbitrary{{"dos"}},
+				ExpectedOutput: "1: Uno\n2: Dos\n3: Tres\n4: Cuatro\nsome prompt (): 2\n",
+			}),
+
+			Entry("when a list of choices is entered", Example{
+				Prompt: "some prompt",
+
+				Input: "3, 1\n",
+
+				ExpectedAnswer: []arbitrary{{"tres"}, {"uno"}},
+				ExpectedOutput: "1: Uno\n2: Dos\n3: Tres\n4: Cuatro\nsome prompt (): 3, 1\n",
+			}),
+
+			Entry("when a range of choices is entered", Example{
+				Prompt: "some prompt",
+
+				Input: "2-4\n",
+
+				ExpectedAnswer: []arbitrary{{"dos"}, {"tres"}, {"cuatro"}},
+				ExpectedOutput: "1: Uno\n2: Dos\n3: Tres\n4: Cuatro\nsome prompt (): 2-4\n",
+			}),
+
+			Entry("when overlapping choices and ranges are entered", Example{
+				Prompt: "some prompt",
+
+				Input: "1,3-4,2-3\n",
+
+				ExpectedAnswer: []arbitrary{{"uno"}, {"tres"}, {"cuatro"}, {"dos"}},
+				ExpectedOutput: "1: Uno\n2: Dos\n3: Tres\n4: Cuatro\nsome prompt (): 1,3-4,2-3\n",
+			}),
+
+			Entry("when a blank line is entered", Example{
+				Prompt: "some prompt",
+
+				Input: "\n",
+
+				ExpectedAnswer: []arbitrary(nil),
+				ExpectedOutput: "1: Uno\n2: Dos\n3: Tres\n4: Cuatro\nsome prompt (): \n",
+			}),
+
+			Entry("when a non-selection is entered, followed by EOF", Example{
+				Prompt: "some prompt",
+
+				Input: "1,5\n",
+
+				ExpectedAnswer: []arbitrary(nil),
+				ExpectedErr:    io.EOF,
+				ExpectedOutput: "1: Uno\n2: Dos\n3: Tres\n4: Cuatro\nsome prompt (): 1,5\ninvalid selection (must be 1-4)\nsome prompt (): ",
+			}),
+
+			Entry("when a backwards range is entered, followed by a range", Example{
+				Prompt: "some prompt",
+
+				Input: "3-1\n1-3\n",
+
+				ExpectedAnswer: []arbitrary{{"uno"}, {"dos"}, {"tres"}},
+				ExpectedOutput: "1: Uno\n2: Dos\n3: Tres\n4: Cuatro\nsome prompt (): 3-1\ninvalid selection (must be 1-4)\nsome prompt (): 1-3\n",
+			}),
+
+			Entry("when a non-integer is entered, followed by a choice", Example{
+				Prompt: "some prompt",
+
+				Input: "1,foo\n4\n",
+
+				ExpectedAnswer: []arbitrary{{"cuatro"}},
+				ExpectedOutput: "1: Uno\n2: Dos\n3: Tres\n4: Cuatro\nsome prompt (): 1,foo\ninvalid selection (not a number)\nsome prompt (): 4\n",
+			}),
+		)
+	})
+
+	Context("when the destination contains some of the choices", func() {
+		BeforeEach(func() {
+			destination = arbsDst([]arbitrary{{"uno"}, {"tres"}})
+		})
+
+		DescribeTable("Resolve", (Example).Run,
+			Entry("when a list of choices is entered", Example{
+				Prompt: "some prompt",
+
+				Input: "2,4\n",
+
+				ExpectedAnswer: []arbitrary{{"dos"}, {"cuatro"}},
+				ExpectedOutput: "1: Uno\n2: Dos\n3: Tres\n4: Cuatro\nsome prompt (1,3): 2,4\n",
+			}),
+
+			Entry("when a blank line is entered", Example{
+				Prompt: "some prompt",
+
+				Input: "\n",
+
+				ExpectedAnswer: []arbitrary{{"uno"}, {"tres"}},
+				ExpectedOutput: "1: Uno\n2: Dos\n3: Tres\n4: Cuatro\nsome prompt (1,3): \n",
+			}),
+
+			Entry("when validation fails, followed by a valid selection", Example{
+				Prompt: "some prompt",
+				Validate: func(value interface{}) error {
+					if len(value.([]arbitrary)) > 2 {
+						return errors.New("pick at most two")
+					}
+
+					return nil
+				},
+
+				Input: "1-3\n2\n",
+
+				ExpectedAnswer: []arbitrary{{"dos"}},
+				ExpectedOutput: "1: Uno\n2: Dos\n3: Tres\n4: Cuatro\nsome prompt (1,3): 1-3\ninvalid selection (pick at most two)\nsome prompt (1,3): 2\n",
+			}),
+		)
+	})
+
+	Context("when the destination contains values that are not choices", func() {
+		BeforeEach(func() {
+			destination = arbsDst([]arbitrary{{"uno"}, {"cinco"}})
+		})
+
+		DescribeTable("Resolve", (Example).Run,
+			Entry("when a blank line is entered, followed by a choice", Example{
+				Prompt: "some prompt",
+
+				Input: "\n2\n",
+
+				ExpectedAnswer: []arbitrary{{"dos"}},
+				ExpectedOutput: "1: Uno\n2: Dos\n3: Tres\n4: Cuatro\nsome prompt: \nsome prompt: 2\n",
+			}),
+		)
+	})
+
+	Context("when the choices are slices themselves", func() {
+		BeforeEach(func() {
+			choices = []interact.Choice{
+				{Display: "Some", Value: []arbitrary{{"uno"}, {"dos"}}},
+				{Display: "None", Value: []arbitrary{}},
+			}
+
+			destination = arbsDst(nil)
+		})
+
+		DescribeTable("Resolve", (Example).Run,
+			Entry("when a choice is entered", Example{
+				Prompt: "some prompt",
+
+				Input: "1\n",
+
+				ExpectedAnswer: []arbitrary{{"uno"}, {"dos"}},
+				ExpectedOutput: "1: Some\n2: None\nsome prompt: 1\n",
+			}),
+		)
+	})
+})
+
+func arbsDst(dst []arbitrary) *[]arbitrary {
+	return &dst
+}
@@ -0,0 +1,105 @@
+package interact
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// numberValue returns the value pointed to by dst if it is of a numeric kind,
+// i.e. any sized int, uint, float, or complex.
+func numberValue(dst interface{}) (reflect.Value, bool) {
+	ptr := reflect.ValueOf(dst)
+	if ptr.Kind() != reflect.Ptr || ptr.IsNil() {
+		return reflect.Value{}, false
+	}
+
+	val := ptr.Elem()
+
+	switch val.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64,
+		reflect.Complex64, reflect.Complex128:
+		return val, true
+	default:
+		return reflect.Value{}, false
+	}
+}
+
+func parseNumber(val reflect.Value, line string) error {
+	bits := val.Type().Bits()
+
+	switch val.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		num, err := strconv.ParseInt(line, 10, bits)
+		if err != nil {
+			return numberError(err)
+		}
+
+		val.SetInt(num)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		num, err := strconv.ParseUint(line, 10, bits)
+		if err != nil {
+			if _, intErr := strconv.ParseInt(line, 10, 64); intErr == nil {
+				// negative numbers are valid, just not here
+				return ErrOutOfRange
+			}
+
+			return numberError(err)
+		}
+
+		val.SetUint(num)
+
+	case reflect.Float32, reflect.Float64:
+		num, err := strconv.ParseFloat(line, bits)
+		if err != nil {
+			return numberError(err)
+		}
+
+		val.SetFloat(num)
+
+	case reflect.Complex64, reflect.Complex128:
+		num, err := strconv.ParseComplex(line, bits)
+		if err != nil {
+			return numberError(err)
+		}
+
+		val.SetComplex(num)
+	}
+
+	return nil
+}
+
+func formatNumber(val reflect.Value) string {
+	bits := val.Type().Bits()
+
+	switch val.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(val.Int(), 10)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(val.Uint(), 10)
+
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(val.Float(), 'g', -1, bits)
+
+	case reflect.Complex64, reflect.Complex128:
+		// strip the surrounding parens; they're not needed to parse it back
+		// and would look odd in the prompt
+		str := strconv.FormatComplex(val.Complex(), 'g', -1, bits)
+		return strings.TrimSuffix(strings.TrimPrefix(str, "("), ")")
+	}
+
+	return ""
+}
+
+func numberError(err error) error {
+	if errors.Is(err, strconv.ErrRange) {
+		return ErrOutOfRange
+	}
+
+	return ErrNotANumber
+}
@@ -0,0 +1,284 @@
+package interact_test
+
+import (
+	"io"
+
+	. "github.com/onsi/ginkgo/v2"
+)
+
+var _ = Describe("Resolving into numbers", func() {
+	Context("when the destination is an int", func() {
+		BeforeEach(func() {
+			destination = intDst(0)
+		})
+
+		DescribeTable("Resolve", (Example).Run,
+			Entry("when a number too large is entered, followed by an integer", Example{
+				Prompt: "some prompt",
+
+				Input: "99999999999999999999\n42\n",
+
+				ExpectedAnswer: 42,
+				ExpectedOutput: "some prompt (0): 99999999999999999999\ninvalid input (out of range)\nsome prompt (0): 42\n",
+			}),
+		)
+	})
+
+	Context("when the destination is an int8", func() {
+		BeforeEach(func() {
+			destination = int8Dst(-5)
+		})
+
+		DescribeTable("Resolve", (Example).Run,
+			Entry("when an integer is entered", Example{
+				Prompt: "some prompt",
+
+				Input: "-128\n",
+
+				ExpectedAnswer: int8(-128),
+				ExpectedOutput: "some prompt (-5): -128\n",
+			}),
+
+			Entry("when a blank line is entered", Example{
+				Prompt: "some prompt",
+
+				Input: "\n",
+
+				ExpectedAnswer: int8(-5),
+				ExpectedOutput: "some prompt (-5): \n",
+			}),
+
+			Entry("when an integer out of range is entered, followed by EOF", Example{
+				Prompt: "some prompt",
+
+				Input: "128\n",
+
+				ExpectedAnswer: int8(-5),
+				ExpectedErr:    io.EOF,
+				ExpectedOutput: "some prompt (-5): 128\ninvalid input (out of range)\nsome prompt (-5): ",
+			}),
+
+			Entry("when a non-integer is entered, followed by an integer", Example{
+				Prompt: "some prompt",
+
+				Input: "foo\n42\n",
+
+				ExpectedAnswer: int8(42),
+				ExpectedOutput: "some prompt (-5): foo\ninvalid input (not a number)\nsome prompt (-5): 42\n",
+			}),
+		)
+	})
+
+	Context("when the destination is an int64", func() {
+		BeforeEach(func() {
+			destination = int64Dst(1 << 40)
+		})
+
+		DescribeTable("Resolve", (Example).Run,
+			Entry("when an integer is entered", Example{
+				Prompt: "some prompt",
+
+				Input: "9223372036854775807\n",
+
+				ExpectedAnswer: int64(9223372036854775807),
+				ExpectedOutput: "some prompt (1099511627776): 9223372036854775807\n",
+			}),
+		)
+	})
+
+	Context("when the destination is a uint16", func() {
+		BeforeEach(func() {
+			destination = uint16Dst(8080)
+		})
+
+		DescribeTable("Resolve", (Example).Run,
+			Entry("when an integer is entered", Example{
+				Prompt: "some prompt",
+
+				Input: "65535\n",
+
+				ExpectedAnswer: uint16(65535),
+				ExpectedOutput: "some prompt (8080): 65535\n",
+			}),
+
+			Entry("when a blank line is entered", Example{
+				Prompt: "some prompt",
+
+				Input: "\n",
+
+				ExpectedAnswer: uint16(8080),
+				ExpectedOutput: "some prompt (8080): \n",
+			}),
+
+			Entry("when an integer too large is entered, followed by an integer", Example{
+				Prompt: "some prompt",
+
+				Input: "65536\n443\n",
+
+				ExpectedAnswer: uint16(443),
+				ExpectedOutput: "some prompt (8080): 65536\ninvalid input (out of range)\nsome prompt (8080): 443\n",
+			}),
+
+			Entry("when a negative integer is entered, followed by an integer", Example{
+				Prompt: "some prompt",
+
+				Input: "-1\n443\n",
+
+				ExpectedAnswer: uint16(443),
+				ExpectedOutput: "some prompt (8080): -1\ninvalid input (out of range)\nsome prompt (8080): 443\n",
+			}),
+
+			Entry("when a non-integer is entered, followed by an integer", Example{
+				Prompt: "some prompt",
+
+				Input: "foo\n443\n",
+
+				ExpectedAnswer: uint16(443),
+				ExpectedOutput: "some prompt (8080): foo\ninvalid input (not a number)\nsome prompt (8080): 443\n",
+			}),
+		)
+	})
+
+	Context("when the destination is a named numeric type", func() {
+		BeforeEach(func() {
+			destination = portDst(22)
+		})
+
+		DescribeTable("Resolve", (Example).Run,
+			Entry("when an integer is entered", Example{
+				Prompt: "some prompt",
+
+				Input: "2222\n",
+
+				ExpectedAnswer: port(2222),
+				ExpectedOutput: "some prompt (22): 2222\n",
+			}),
+		)
+	})
+
+	Context("when the destination is a float32", func() {
+		BeforeEach(func() {
+			destination = float32Dst(0.5)
+		})
+
+		DescribeTable("Resolve", (Example).Run,
+			Entry("when a number is entered", Example{
+				Prompt: "some prompt",
+
+				Input: "12.25\n",
+
+				ExpectedAnswer: float32(12.25),
+				ExpectedOutput: "some prompt (0.5): 12.25\n",
+			}),
+
+			Entry("when a number too large is entered, followed by a number", Example{
+				Prompt: "some prompt",
+
+				Input: "1e39\n1e38\n",
+
+				ExpectedAnswer: float32(1e38),
+				ExpectedOutput: "some prompt (0.5): 1e39\ninvalid input (out of range)\nsome prompt (0.5): 1e38\n",
+			}),
+		)
+	})
+
+	Context("when the destination is a float64", func() {
+		BeforeEach(func() {
+			destination = float64Dst(99.9)
+		})
+
+		DescribeTable("Resolve", (Example).Run,
+			Entry("when a number is entered", Example{
+				Prompt: "some prompt",
+
+				Input: "0.125\n",
+
+				ExpectedAnswer: 0.125,
+				ExpectedOutput: "some prompt (99.9): 0.125\n",
+			}),
+
+			Entry("when a blank line is entered", Example{
+				Prompt: "some prompt",
+
+				Input: "\n",
+
+				ExpectedAnswer: 99.9,
+				ExpectedOutput: "some prompt (99.9): \n",
+			}),
+
+			Entry("when a non-number is entered, followed by a number", Example{
+				Prompt: "some prompt",
+
+				Input: "foo\n1\n",
+
+				ExpectedAnswer: 1.0,
+				ExpectedOutput: "some prompt (99.9): foo\ninvalid input (not a number)\nsome prompt (99.9): 1\n",
+			}),
+		)
+	})
+
+	Context("when the destination is a complex128", func() {
+		BeforeEach(func() {
+			destination = complex128Dst(1 + 2i)
+		})
+
+		DescribeTable("Resolve", (Example).Run,
+			Entry("when a number is entered", Example{
+				Prompt: "some prompt",
+
+				Input: "3-4i\n",
+
+				ExpectedAnswer: 3 - 4i,
+				ExpectedOutput: "some prompt (1+2i): 3-4i\n",
+			}),
+
+			Entry("when a blank line is entered", Example{
+				Prompt: "some prompt",
+
+				Input: "\n",
+
+				ExpectedAnswer: 1 + 2i,
+				ExpectedOutput: "some prompt (1+2i): \n",
+			}),
+
+			Entry("when a non-number is entered, followed by a number", Example{
+				Prompt: "some prompt",
+
+				Input: "foo\n5i\n",
+
+				ExpectedAnswer: 5i,
+				ExpectedOutput: "some prompt (1+2i): foo\ninvalid input (not a number)\nsome prompt (1+2i): 5i\n",
+			}),
+		)
+	})
+})
+
+type port uint16
+
+func portDst(dst port) *port {
+	return &dst
+}
+
+func int8Dst(dst int8) *int8 {
+	return &dst
+}
+
+func int64Dst(dst int64) *int64 {
+	return &dst
+}
+
+func uint16Dst(dst uint16) *uint16 {
+	return &dst
+}
+
+func float32Dst(dst float32) *float32 {
+	return &dst
+}
+
+func float64Dst(dst float64) *float64 {
+	return &dst
+}
+
+func complex128Dst(dst complex128) *complex128 {
+	return &dst
+}
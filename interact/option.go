@@ -0,0 +1,130 @@
+package interact
+
+import (
+	"fmt"
+	"io"
+)
+
+// Option configures an Interaction before it is resolved, e.g. by Ask or
+// Choose.
+type Option func(*Interaction)
+
+// WithInput sets the Input of the Interaction.
+func WithInput(input io.Reader) Option {
+	return func(interaction *Interaction) {
+		interaction.Input = input
+	}
+}
+
+// WithOutput sets the Output of the Interaction.
+func WithOutput(output io.Writer) Option {
+	return func(interaction *Interaction) {
+		interaction.Output = output
+	}
+}
+
+// WithComplete sets the Complete hook of the Interaction.
+func WithComplete(complete func(line string, pos int) []string) Option {
+	return func(interaction *Interaction) {
+		interaction.Complete = complete
+	}
+}
+
+// WithEditDefault sets EditDefault on the Interaction, so that the default
+// can be edited in place on a terminal.
+func WithEditDefault() Option {
+	return func(interaction *Interaction) {
+		interaction.EditDefault = true
+	}
+}
+
+// WithEditor sets the Editor of the Interaction.
+func WithEditor(editor Editor) Option {
+	return func(interaction *Interaction) {
+		interaction.Editor = &editor
+	}
+}
+
+// WithHistory sets the History of the Interaction.
+func WithHistory(history *History) Option {
+	return func(interaction *Interaction) {
+		interaction.History = history
+	}
+}
+
+// WithName sets the Name of the Interaction.
+func WithName(name string) Option {
+	return func(interaction *Interaction) {
+		interaction.Name = name
+	}
+}
+
+// WithAnswers sets the Answers of the Interaction.
+func WithAnswers(answers Answers) Option {
+	return func(interaction *Interaction) {
+		interaction.Answers = answers
+	}
+}
+
+// WithSources appends to the Sources of the Interaction.
+func WithSources(sources ...Source) Option {
+	return func(interaction *Interaction) {
+		interaction.Sources = append(interaction.Sources, sources...)
+	}
+}
+
+// WithNonInteractive sets the NonInteractive policy of the Interaction.
+func WithNonInteractive(policy NonInteractivePolicy) Option {
+	return func(interaction *Interaction) {
+		interaction.NonInteractive = policy
+	}
+}
+
+// WithRecorder sets the Recorder of the Interaction.
+func WithRecorder(recorder *Recorder) Option {
+	return func(interaction *Interaction) {
+		interaction.Recorder = recorder
+	}
+}
+
+// WithReplayer sets the Replayer of the Interaction.
+func WithReplayer(replayer *Replayer) Option {
+	return func(interaction *Interaction) {
+		interaction.Replayer = replayer
+	}
+}
+
+// WithProtocol sets the Protocol of the Interaction.
+func WithProtocol(protocol Protocol) Option {
+	return func(interaction *Interaction) {
+		interaction.Protocol = protocol
+	}
+}
+
+// WithTimeLayout sets the TimeLayout of the Interaction.
+func WithTimeLayout(layout string) Option {
+	return func(interaction *Interaction) {
+		interaction.TimeLayout = layout
+	}
+}
+
+// WithValidate sets the Validate hook of the Interaction to a function
+// accepting the destination's type.
+func WithValidate[T any](validate func(T) error) Option {
+	return func(interaction *Interaction) {
+		interaction.Validate = func(value interface{}) error {
+			typed, ok := value.(T)
+			if !ok {
+				return fmt.Errorf("expected %T, got %T", typed, value)
+			}
+
+			return validate(typed)
+		}
+	}
+}
+
+func (interaction *Interaction) apply(opts []Option) {
+	for _, opt := range opts {
+		opt(interaction)
+	}
+}
@@ -0,0 +1,85 @@
+package interact
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ErrNotInteractive is returned by Resolve when the user cannot be asked
+// because the input is not a terminal, depending on the interaction's
+// NonInteractive policy.
+var ErrNotInteractive = errors.New("input is not interactive")
+
+// NonInteractivePolicy determines what Resolve does when the input is not a
+// terminal, e.g. when running from cron or CI.
+type NonInteractivePolicy int
+
+const (
+	// NonInteractivePrompt reads the answer from the input anyway, as if the
+	// user had typed it. This is the default, allowing input to be piped in.
+	NonInteractivePrompt NonInteractivePolicy = iota
+
+	// NonInteractiveFail returns ErrNotInteractive without reading anything.
+	NonInteractiveFail
+
+	// NonInteractiveDefault uses the default held in the destination without
+	// reading anything. A RequiredDestination, or a default which is not one
+	// of the choices, results in ErrNotInteractive.
+	NonInteractiveDefault
+
+	// NonInteractiveAssumeYes is like NonInteractiveDefault, but answers yes
+	// to any bool destination, e.g. confirmations.
+	NonInteractiveAssumeYes
+)
+
+// resolveNonInteractive resolves dst according to the interaction's
+// NonInteractive policy, which must not be NonInteractivePrompt.
+func (interaction Interaction) resolveNonInteractive(dst interface{}) error {
+	if interaction.NonInteractive == NonInteractiveFail {
+		return fmt.Errorf("cannot ask %q: %w", interaction.Prompt, ErrNotInteractive)
+	}
+
+	required, isRequired := dst.(RequiredDestination)
+	if isRequired {
+		dst = required.Destination
+	}
+
+	if interaction.NonInteractive == NonInteractiveAssumeYes && len(interaction.Choices) == 0 {
+		if _, ok := dst.(*bool); ok {
+			return interaction.useDefault(dst, reflect.ValueOf(true))
+		}
+	}
+
+	noDefault := fmt.Errorf("%q has no default: %w", interaction.Prompt, ErrNotInteractive)
+
+	if isRequired {
+		return noDefault
+	}
+
+	if interaction.isMultipleChoice(dst) {
+		if _, present := interaction.choiceNumbers(dst); !present {
+			return noDefault
+		}
+	} else if len(interaction.Choices) > 0 {
+		if _, present := interaction.choiceNumber(dst); !present {
+			return noDefault
+		}
+	}
+
+	return interaction.useDefault(dst, reflect.ValueOf(dst).Elem())
+}
+
+// useDefault validates the given value and stores it in dst.
+func (interaction Interaction) useDefault(dst interface{}, value reflect.Value) error {
+	if interaction.Validate != nil {
+		err := interaction.Validate(value.Interface())
+		if err != nil {
+			return fmt.Errorf("invalid default for %q: %w", interaction.Prompt, err)
+		}
+	}
+
+	reflect.ValueOf(dst).Elem().Set(value)
+
+	return nil
+}
@@ -0,0 +1,161 @@
+package interact_test
+
+import (
+	"bytes"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+
+	"github.com/vito/go-interact/interact"
+)
+
+var _ = Describe("Resolving when the input is not interactive", func() {
+	var (
+		output *gbytes.Buffer
+
+		interaction interact.Interaction
+	)
+
+	BeforeEach(func() {
+		output = gbytes.NewBuffer()
+
+		interaction = interact.NewInteraction("some prompt")
+		interaction.Input = new(errReader)
+		interaction.Output = output
+	})
+
+	Context("with the default policy", func() {
+		It("reads the answer from the input", func() {
+			interaction.Input = bytes.NewBufferString("forty two\n")
+
+			var thing string
+			Expect(interaction.Resolve(&thing)).To(Succeed())
+			Expect(thing).To(Equal("forty two"))
+		})
+	})
+
+	Context("when failing", func() {
+		BeforeEach(func() {
+			interaction.NonInteractive = interact.NonInteractiveFail
+		})
+
+		It("returns ErrNotInteractive without reading or prompting", func() {
+			thing := "some default"
+			err := interaction.Resolve(&thing)
+			Expect(err).To(MatchError(interact.ErrNotInteractive))
+			Expect(err).To(MatchError(`cannot ask "some prompt": input is not interactive`))
+			Expect(thing).To(Equal("some default"))
+
+			Expect(output.Contents()).To(BeEmpty())
+		})
+
+		It("returns a MissingValueError if the interaction has sources", func() {
+			interaction.Sources = []interact.Source{interact.Env("INTERACT_TEST_MISSING")}
+
+			var thing string
+			err := interaction.Resolve(&thing)
+			Expect(err).To(BeAssignableToTypeOf(interact.MissingValueError{}))
+			Expect(err).To(MatchError(interact.ErrNotInteractive))
+		})
+	})
+
+	Context("when using defaults", func() {
+		BeforeEach(func() {
+			interaction.NonInteractive = interact.NonInteractiveDefault
+		})
+
+		It("keeps the default without reading or prompting", func() {
+			thing := "some default"
+			Expect(interaction.Resolve(&thing)).To(Succeed())
+			Expect(thing).To(Equal("some default"))
+
+			Expect(output.Contents()).To(BeEmpty())
+		})
+
+		It("uses the default instead of requiring a source", func() {
+			interaction.Sources = []interact.Source{interact.Env("INTERACT_TEST_MISSING")}
+
+			port := 80
+			Expect(interaction.Resolve(&port)).To(Succeed())
+			Expect(port).To(Equal(80))
+		})
+
+		It("returns ErrNotInteractive for a required destination", func() {
+			var thing string
+			err := interaction.Resolve(interact.Required(&thing))
+			Expect(err).To(MatchError(interact.ErrNotInteractive))
+			Expect(err).To(MatchError(`"some prompt" has no default: input is not interactive`))
+		})
+
+		It("returns ErrNotInteractive if the default is not one of the choices", func() {
+			interaction.Choices = []interact.Choice{
+				{Display: "One", Value: 1},
+				{Display: "Two", Value: 2},
+			}
+
+			two := 2
+			Expect(interaction.Resolve(&two)).To(Succeed())
+			Expect(two).To(Equal(2))
+
+			three := 3
+			Expect(interaction.Resolve(&three)).To(MatchError(interact.ErrNotInteractive))
+		})
+
+		It("returns an error if the default is not valid", func() {
+			interaction.Validate = func(interface{}) error {
+				return errors.New("nope")
+			}
+
+			thing := "some default"
+			Expect(interaction.Resolve(&thing)).To(MatchError(`invalid default for "some prompt": nope`))
+		})
+
+		It("does not assume yes", func() {
+			confirmed := false
+			Expect(interaction.Resolve(&confirmed)).To(Succeed())
+			Expect(confirmed).To(BeFalse())
+		})
+	})
+
+	Context("when assuming yes", func() {
+		BeforeEach(func() {
+			interaction.NonInteractive = interact.NonInteractiveAssumeYes
+		})
+
+		It("answers yes to bools", func() {
+			confirmed := false
+			Expect(interaction.Resolve(&confirmed)).To(Succeed())
+			Expect(confirmed).To(BeTrue())
+
+			var required bool
+			Expect(interaction.Resolve(interact.Required(&required))).To(Succeed())
+			Expect(required).To(BeTrue())
+		})
+
+		It("uses the default for anything else", func() {
+			thing := "some default"
+			Expect(interaction.Resolve(&thing)).To(Succeed())
+			Expect(thing).To(Equal("some default"))
+		})
+	})
+
+	Context("in a flow", func() {
+		It("applies the flow's policy to every step", func() {
+			var name string
+			confirmed := false
+
+			flow := interact.NewFlow(
+				interact.NewStep("Name", &name),
+				interact.NewStep("Continue?", &confirmed),
+			)
+			flow.Input = new(errReader)
+			flow.Output = output
+			flow.NonInteractive = interact.NonInteractiveAssumeYes
+
+			Expect(flow.Resolve()).To(Succeed())
+			Expect(confirmed).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,14 @@
+//go:build !(aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris || zos)
+
+package interact
+
+import (
+	"context"
+	"os"
+)
+
+// waitForInput cannot wait on files on this platform, so reads from them
+// are left pending as with any other reader.
+func waitForInput(ctx context.Context, file *os.File) (bool, error) {
+	return false, nil
+}
@@ -0,0 +1,54 @@
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris || zos
+
+package interact
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// pollInterval is how long each poll waits for input before checking
+// whether the context is done, in milliseconds.
+const pollInterval = 50
+
+// waitForInput waits until there is input to read from the file, returning
+// the context's error if it is done first. It returns false if the file
+// cannot be waited on.
+func waitForInput(ctx context.Context, file *os.File) (bool, error) {
+	// SyscallConn, unlike Fd, leaves the file in non-blocking mode if it was
+	conn, err := file.SyscallConn()
+	if err != nil {
+		return false, nil
+	}
+
+	for {
+		var ready int
+		var pollErr error
+		err := conn.Control(func(fd uintptr) {
+			fds := []unix.PollFd{{Fd: int32(fd), Events: unix.POLLIN}}
+			ready, pollErr = unix.Poll(fds, pollInterval)
+		})
+		if err != nil {
+			return false, nil
+		}
+
+		if errors.Is(pollErr, unix.EINTR) {
+			continue
+		}
+
+		if pollErr != nil {
+			return false, nil
+		}
+
+		if ready > 0 {
+			return true, nil
+		}
+
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
+	}
+}
@@ -0,0 +1,320 @@
+package interact
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// Protocol determines how questions are asked and answered.
+type Protocol string
+
+const (
+	// ProtocolText asks questions as human-readable prompts, either on a
+	// terminal or as plain lines of text.
+	ProtocolText Protocol = ""
+
+	// ProtocolJSON asks each question as a line of JSON, and reads each
+	// answer as a line of JSON, for use by other programs such as editors or
+	// GUIs.
+	//
+	// Each question is written to Output as a Question, with an Event of
+	// "question". If an answer is not accepted, a Question with an Event of
+	// "retry" and a Message explaining why is written, followed by the
+	// question again.
+	//
+	// Each answer is read from Input as an Answer. Answers are interpreted as
+	// with Answers, so choices are answered by their Display or Value.
+	ProtocolJSON Protocol = "json"
+)
+
+// ProtocolEnv is the environment variable read by ProtocolFromEnv, so that a
+// frontend can drive a program which opts in by setting e.g.
+// INTERACT_PROTOCOL=json.
+const ProtocolEnv = "INTERACT_PROTOCOL"
+
+// ProtocolFromEnv returns the Protocol named by $INTERACT_PROTOCOL, if any.
+// Programs that want to be driven by a frontend use it to set the Protocol
+// of their interactions, e.g.:
+//
+//	interaction.Protocol = interact.ProtocolFromEnv()
+func ProtocolFromEnv() Protocol {
+	return Protocol(os.Getenv(ProtocolEnv))
+}
+
+// Question is written by ProtocolJSON to ask a question, or to explain why
+// an answer was not accepted.
+type Question struct {
+	// Event is "question" or "retry".
+	Event string `json:"event"`
+
+	Name   string `json:"name,omitempty"`
+	Prompt string `json:"prompt,omitempty"`
+
+	// Type is the type of value being asked for, e.g. "string", "bool",
+	// "int", "duration", "time", "password", "multiline", "choice", or "text"
+	// for values implementing Resolver or encoding.TextUnmarshaler.
+	Type string `json:"type,omitempty"`
+
+	// Default is the value used if the answer is blank, as it would be
+	// entered. For choices it is the Display of the default choice, or a list
+	// of them if Multiple is true. Passwords never include their default.
+	Default interface{} `json:"default,omitempty"`
+
+	Choices  []string `json:"choices,omitempty"`
+	Multiple bool     `json:"multiple,omitempty"`
+	Required bool     `json:"required,omitempty"`
+	Secret   bool     `json:"secret,omitempty"`
+
+	// Message explains why an answer was not accepted.
+	Message string `json:"message,omitempty"`
+}
+
+// Answer is read by ProtocolJSON in response to a Question.
+type Answer struct {
+	// Answer is the answer to the question; a string, number, bool, or list
+	// of them for multiple choices.
+	Answer interface{} `json:"answer"`
+
+	// Back, if true, goes back to the previous question of a Flow, if going
+	// back is allowed.
+	Back bool `json:"back,omitempty"`
+}
+
+// jsonUser asks questions using ProtocolJSON.
+type jsonUser struct {
+	interaction Interaction
+	dst         interface{}
+
+	// input is read a byte at a time, so that nothing past the answer is
+	// consumed
+	input   nonTTYUser
+	encoder *json.Encoder
+}
+
+func newJSONUser(interaction Interaction, dst interface{}, input io.Reader, output io.Writer) *jsonUser {
+	return &jsonUser{
+		interaction: interaction,
+		dst:         dst,
+
+		input:   newNonTTYUser(input, nil),
+		encoder: json.NewEncoder(output),
+	}
+}
+
+// WriteLine is only used to explain why an answer was not accepted.
+func (u *jsonUser) WriteLine(line string) error {
+	return u.encoder.Encode(Question{
+		Event:   "retry",
+		Name:    u.interaction.Name,
+		Prompt:  u.interaction.Prompt,
+		Message: line,
+	})
+}
+
+func (u *jsonUser) ReadLine(prompt string) (string, error) {
+	for {
+		lines, err := u.ask()
+		if err != nil {
+			return "", err
+		}
+
+		if len(lines) != 1 {
+			u.WriteLine(fmt.Sprintf("invalid input (expected a single answer, got %d)", len(lines)))
+			continue
+		}
+
+		return boolAnswer(u.dst, lines[0]), nil
+	}
+}
+
+func (u *jsonUser) ReadPassword(prompt string) (string, error) {
+	return u.ReadLine(prompt)
+}
+
+func (u *jsonUser) ReadLines(prompt string) (string, error) {
+	return u.ReadLine(prompt)
+}
+
+func (u *jsonUser) Select(prompt string, options []string, initial int) (int, error) {
+	for {
+		lines, err := u.ask()
+		if err != nil {
+			return 0, err
+		}
+
+		if len(lines) != 1 {
+			u.WriteLine(fmt.Sprintf("invalid selection (expected a single answer, got %d)", len(lines)))
+			continue
+		}
+
+		if lines[0] == "" {
+			if _, present := u.interaction.choiceNumber(u.dst); present {
+				return initial, nil
+			}
+		}
+
+		num, err := u.interaction.matchChoice(lines[0])
+		if err != nil {
+			u.WriteLine(fmt.Sprintf("invalid selection (%s)", err))
+			continue
+		}
+
+		return num - 1, nil
+	}
+}
+
+func (u *jsonUser) SelectMultiple(prompt string, options []string, selected []int) ([]int, error) {
+	for {
+		lines, err := u.ask()
+		if err != nil {
+			return nil, err
+		}
+
+		if len(lines) == 1 && lines[0] == "" {
+			if _, present := u.interaction.choiceNumbers(u.dst); present {
+				return selected, nil
+			}
+		}
+
+		idxs, err := u.matchChoices(lines)
+		if err != nil {
+			u.WriteLine(fmt.Sprintf("invalid selection (%s)", err))
+			continue
+		}
+
+		return idxs, nil
+	}
+}
+
+func (u *jsonUser) matchChoices(lines []string) ([]int, error) {
+	idxs := []int{}
+	for _, line := range lines {
+		num, err := u.interaction.matchChoice(line)
+		if err != nil {
+			return nil, err
+		}
+
+		idxs = append(idxs, num-1)
+	}
+
+	return idxs, nil
+}
+
+// ask writes the question and reads the answer, returning it as the lines
+// the user would have entered. If the answer cannot be read, it explains why
+// and asks again.
+func (u *jsonUser) ask() ([]string, error) {
+	for {
+		err := u.encoder.Encode(u.question())
+		if err != nil {
+			return nil, err
+		}
+
+		line, err := u.input.readLine()
+		if err != nil {
+			return nil, err
+		}
+
+		var answer Answer
+		err = json.Unmarshal([]byte(line), &answer)
+		if err != nil {
+			u.WriteLine(fmt.Sprintf("invalid answer (%s)", err))
+			continue
+		}
+
+		if answer.Back && u.interaction.back != "" {
+			return nil, errBack
+		}
+
+		lines, err := u.interaction.answerLines(answer.Answer)
+		if err != nil {
+			u.WriteLine(fmt.Sprintf("invalid answer (%s)", err))
+			continue
+		}
+
+		return lines, nil
+	}
+}
+
+// question describes what is being asked for.
+func (u *jsonUser) question() Question {
+	interaction := u.interaction
+	dst := u.dst
+
+	question := Question{
+		Event:  "question",
+		Name:   interaction.Name,
+		Prompt: interaction.Prompt,
+	}
+
+	if required, ok := dst.(RequiredDestination); ok {
+		dst = required.Destination
+		question.Required = true
+	}
+
+	question.Type = valueType(dst)
+
+	if _, ok := dst.(*Password); ok {
+		question.Secret = true
+	}
+
+	switch {
+	case interaction.isMultipleChoice(dst):
+		question.Type = "choice"
+		question.Choices = interaction.choiceDisplays()
+		question.Multiple = true
+
+		if nums, present := interaction.choiceNumbers(dst); present && !question.Required {
+			displays := []string{}
+			for _, num := range nums {
+				displays = append(displays, interaction.Choices[num-1].Display)
+			}
+
+			question.Default = displays
+		}
+
+	case len(interaction.Choices) > 0:
+		question.Type = "choice"
+		question.Choices = interaction.choiceDisplays()
+
+		if num, present := interaction.choiceNumber(dst); present && !question.Required {
+			question.Default = interaction.Choices[num-1].Display
+		}
+
+	case !question.Required && !question.Secret:
+		if def, ok := interaction.format(dst); ok {
+			question.Default = def
+		}
+	}
+
+	return question
+}
+
+// valueType names the type of value held in dst.
+func valueType(dst interface{}) string {
+	switch dst.(type) {
+	case Resolver:
+		return "text"
+	case *time.Duration:
+		return "duration"
+	case *time.Time:
+		return "time"
+	case *Password:
+		return "password"
+	case *Multiline:
+		return "multiline"
+	case *string:
+		return "string"
+	case *bool:
+		return "bool"
+	}
+
+	if val, ok := numberValue(dst); ok {
+		return val.Kind().String()
+	}
+
+	return "text"
+}
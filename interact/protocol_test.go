@@ -0,0 +1,246 @@
+package interact_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vito/go-interact/interact"
+)
+
+var _ = Describe("Resolving with the JSON protocol", func() {
+	var (
+		input  *bytes.Buffer
+		output *bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		input = new(bytes.Buffer)
+		output = new(bytes.Buffer)
+	})
+
+	resolve := func(interaction interact.Interaction, dst interface{}) error {
+		interaction.Protocol = interact.ProtocolJSON
+		interaction.Input = input
+		interaction.Output = output
+		return interaction.Resolve(dst)
+	}
+
+	questions := func() []interact.Question {
+		var questions []interact.Question
+
+		decoder := json.NewDecoder(output)
+		for decoder.More() {
+			var question interact.Question
+			Expect(decoder.Decode(&question)).To(Succeed())
+			questions = append(questions, question)
+		}
+
+		return questions
+	}
+
+	It("asks the question as JSON, with the default", func() {
+		input.WriteString(`{"answer": "alice"}` + "\n")
+
+		username := "root"
+		Expect(resolve(interact.NewInteraction("Username"), &username)).To(Succeed())
+		Expect(username).To(Equal("alice"))
+
+		Expect(output.String()).To(Equal(`{"event":"question","prompt":"Username","type":"string","default":"root"}` + "\n"))
+	})
+
+	It("keeps the default when the answer is blank", func() {
+		input.WriteString(`{"answer": null}` + "\n")
+
+		port := 80
+		Expect(resolve(interact.NewInteraction("Port"), &port)).To(Succeed())
+		Expect(port).To(Equal(80))
+	})
+
+	It("explains why an answer was not accepted, and asks again", func() {
+		input.WriteString(`{"answer": "http"}` + "\n" + `{"answer": 8080}` + "\n")
+
+		interaction := interact.NewInteraction("Port")
+		interaction.Name = "port"
+
+		port := 80
+		Expect(resolve(interaction, &port)).To(Succeed())
+		Expect(port).To(Equal(8080))
+
+		Expect(questions()).To(Equal([]interact.Question{
+			{Event: "question", Name: "port", Prompt: "Port", Type: "int", Default: "80"},
+			{Event: "retry", Name: "port", Prompt: "Port", Message: "invalid input (not a number)"},
+			{Event: "question", Name: "port", Prompt: "Port", Type: "int", Default: "80"},
+		}))
+	})
+
+	It("marks required and secret questions, never showing a password", func() {
+		input.WriteString(`{"answer": "hunter2"}` + "\n")
+
+		password := interact.Password("secret")
+		Expect(resolve(interact.NewInteraction("Password"), interact.Required(&password))).To(Succeed())
+		Expect(password).To(Equal(interact.Password("hunter2")))
+
+		Expect(questions()).To(Equal([]interact.Question{
+			{Event: "question", Prompt: "Password", Type: "password", Required: true, Secret: true},
+		}))
+	})
+
+	It("accepts answers spanning several lines", func() {
+		input.WriteString(`{"answer": "first line\nsecond line"}` + "\n")
+
+		var description interact.Multiline
+		Expect(resolve(interact.NewInteraction("Description"), &description)).To(Succeed())
+		Expect(description).To(Equal(interact.Multiline("first line\nsecond line")))
+
+		Expect(questions()).To(Equal([]interact.Question{
+			{Event: "question", Prompt: "Description", Type: "multiline", Default: ""},
+		}))
+	})
+
+	It("reads only as far as the answer", func() {
+		input.WriteString(`{"answer": "alice"}` + "\n" + `{"answer": true}` + "\n")
+
+		var username string
+		Expect(resolve(interact.NewInteraction("Username"), &username)).To(Succeed())
+		Expect(username).To(Equal("alice"))
+
+		var admin bool
+		Expect(resolve(interact.NewInteraction("Admin?"), &admin)).To(Succeed())
+		Expect(admin).To(BeTrue())
+	})
+
+	It("explains that an answer is not JSON, and asks again", func() {
+		input.WriteString("alice\n" + `{"answer": "alice"}` + "\n")
+
+		var username string
+		Expect(resolve(interact.NewInteraction("Username"), &username)).To(Succeed())
+		Expect(username).To(Equal("alice"))
+
+		retry := questions()[1]
+		Expect(retry.Event).To(Equal("retry"))
+		Expect(retry.Message).To(HavePrefix("invalid answer ("))
+	})
+
+	It("explains that a list is not a single answer, and asks again", func() {
+		input.WriteString(`{"answer": ["alice", "bob"]}` + "\n" + `{"answer": "alice"}` + "\n")
+
+		var username string
+		Expect(resolve(interact.NewInteraction("Username"), &username)).To(Succeed())
+		Expect(username).To(Equal("alice"))
+
+		Expect(questions()).To(Equal([]interact.Question{
+			{Event: "question", Prompt: "Username", Type: "string", Default: ""},
+			{Event: "retry", Prompt: "Username", Message: "invalid input (expected a single answer, got 2)"},
+			{Event: "question", Prompt: "Username", Type: "string", Default: ""},
+		}))
+	})
+
+	It("returns an error when the input ends", func() {
+		var username string
+		Expect(resolve(interact.NewInteraction("Username"), &username)).To(Equal(io.EOF))
+	})
+
+	Context("when the interaction has choices", func() {
+		var interaction interact.Interaction
+
+		BeforeEach(func() {
+			interaction = interact.NewInteraction("Number",
+				interact.Choice{Display: "Uno", Value: 1},
+				interact.Choice{Display: "Dos", Value: 2},
+				interact.Choice{Display: "Tres", Value: 3},
+			)
+		})
+
+		It("lists the choices, and accepts their display or value", func() {
+			input.WriteString(`{"answer": "Cuatro"}` + "\n" + `{"answer": "Dos"}` + "\n")
+
+			number := 1
+			Expect(resolve(interaction, &number)).To(Succeed())
+			Expect(number).To(Equal(2))
+
+			input.WriteString(`{"answer": 3}` + "\n")
+			Expect(resolve(interaction, &number)).To(Succeed())
+			Expect(number).To(Equal(3))
+
+			Expect(questions()).To(Equal([]interact.Question{
+				{Event: "question", Prompt: "Number", Type: "choice", Choices: []string{"Uno", "Dos", "Tres"}, Default: "Uno"},
+				{Event: "retry", Prompt: "Number", Message: `invalid selection (no choice matching "Cuatro")`},
+				{Event: "question", Prompt: "Number", Type: "choice", Choices: []string{"Uno", "Dos", "Tres"}, Default: "Uno"},
+				{Event: "question", Prompt: "Number", Type: "choice", Choices: []string{"Uno", "Dos", "Tres"}, Default: "Dos"},
+			}))
+		})
+
+		It("asks for a required choice without a default, until one is made", func() {
+			input.WriteString(`{"answer": ""}` + "\n" + `{"answer": "Tres"}` + "\n")
+
+			number := 1
+			Expect(resolve(interaction, interact.Required(&number))).To(Succeed())
+			Expect(number).To(Equal(3))
+
+			Expect(questions()).To(Equal([]interact.Question{
+				{Event: "question", Prompt: "Number", Type: "choice", Choices: []string{"Uno", "Dos", "Tres"}, Required: true},
+				{Event: "retry", Prompt: "Number", Message: `invalid selection (no choice matching "")`},
+				{Event: "question", Prompt: "Number", Type: "choice", Choices: []string{"Uno", "Dos", "Tres"}, Required: true},
+			}))
+		})
+
+		It("keeps the default choice when the answer is blank", func() {
+			input.WriteString(`{"answer": ""}` + "\n")
+
+			number := 3
+			Expect(resolve(interaction, &number)).To(Succeed())
+			Expect(number).To(Equal(3))
+		})
+
+		It("accepts a list when selecting multiple", func() {
+			input.WriteString(`{"answer": ["Uno", 3]}` + "\n")
+
+			numbers := []int{2}
+			Expect(resolve(interaction, &numbers)).To(Succeed())
+			Expect(numbers).To(Equal([]int{1, 3}))
+
+			Expect(questions()).To(Equal([]interact.Question{
+				{Event: "question", Prompt: "Number", Type: "choice", Choices: []string{"Uno", "Dos", "Tres"}, Multiple: true, Default: []interface{}{"Dos"}},
+			}))
+		})
+	})
+
+	Context("in a flow that allows going back", func() {
+		It("goes back when asked to", func() {
+			input.WriteString(strings.Join([]string{
+				`{"answer": "alice"}`,
+				`{"back": true}`,
+				`{"answer": "bob"}`,
+				`{"answer": 8080}`,
+			}, "\n") + "\n")
+
+			var username string
+			var port int
+
+			flow := interact.NewFlow(
+				interact.NewStep("Username", &username),
+				interact.NewStep("Port", &port),
+			)
+			flow.Input = input
+			flow.Output = output
+			flow.BackInput = "<"
+			flow.Steps[0].Protocol = interact.ProtocolJSON
+			flow.Steps[1].Protocol = interact.ProtocolJSON
+
+			Expect(flow.Resolve()).To(Succeed())
+			Expect(username).To(Equal("bob"))
+			Expect(port).To(Equal(8080))
+		})
+	})
+
+	It("is taken from $INTERACT_PROTOCOL only when asked to", func() {
+		GinkgoT().Setenv(interact.ProtocolEnv, "json")
+		Expect(interact.ProtocolFromEnv()).To(Equal(interact.ProtocolJSON))
+		Expect(interact.NewInteraction("Username").Protocol).To(Equal(interact.ProtocolText))
+	})
+})
@@ -0,0 +1,367 @@
+package interact
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ErrRedacted is returned when replaying a password which was redacted from
+// the transcript.
+var ErrRedacted = errors.New("answer was redacted from the transcript")
+
+// ErrTranscriptEnded is returned when replaying more interactions than were
+// recorded.
+var ErrTranscriptEnded = errors.New("no more interactions in the transcript")
+
+// Exchange is a transcript of a single interaction: the question asked, and
+// each answer the user gave until one was accepted.
+type Exchange struct {
+	Time time.Time `json:"time"`
+
+	Name    string   `json:"name,omitempty"`
+	Prompt  string   `json:"prompt"`
+	Choices []string `json:"choices,omitempty"`
+
+	Attempts []Attempt `json:"attempts"`
+
+	// Value is the resulting value, as shown by a Flow's review. Passwords
+	// are masked.
+	Value string `json:"value,omitempty"`
+
+	// Error is the error that ended the interaction, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// Attempt is a single answer given by the user.
+type Attempt struct {
+	Time time.Time `json:"time"`
+
+	// Input is the line the user entered. Menu selections are recorded as
+	// the numbers of the chosen choices.
+	Input string `json:"input"`
+
+	// Redacted is true if the input was a password, which is not recorded.
+	Redacted bool `json:"redacted,omitempty"`
+
+	// Retry is the message shown to the user if the input was not accepted.
+	Retry string `json:"retry,omitempty"`
+}
+
+// Recorder records a transcript of each interaction resolved with it, writing
+// each Exchange as a line of JSON.
+type Recorder struct {
+	writer io.Writer
+
+	mutex     sync.Mutex
+	exchanges []Exchange
+}
+
+// NewRecorder constructs a recorder writing to the given writer.
+func NewRecorder(writer io.Writer) *Recorder {
+	return &Recorder{writer: writer}
+}
+
+// Exchanges returns everything recorded so far.
+func (recorder *Recorder) Exchanges() []Exchange {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	return append([]Exchange(nil), recorder.exchanges...)
+}
+
+// record resolves dst while recording the user's input.
+func (recorder *Recorder) record(interaction Interaction, dst interface{}, user userIO, prompt string) error {
+	exchange := &Exchange{
+		Time:   time.Now(),
+		Name:   interaction.Name,
+		Prompt: interaction.Prompt,
+	}
+
+	if len(interaction.Choices) > 0 {
+		exchange.Choices = interaction.choiceDisplays()
+	}
+
+	recording := &recordingUser{
+		userIO:   user,
+		exchange: exchange,
+		back:     interaction.back,
+	}
+
+	if menu, isMenu := user.(selector); isMenu {
+		user = recordingSelector{recording, menu}
+	} else {
+		user = recording
+	}
+
+	err := interaction.resolveWith(dst, user, prompt)
+	if err != nil {
+		exchange.Error = err.Error()
+	} else {
+		exchange.Value = interaction.formatAnswer(dst)
+	}
+
+	writeErr := recorder.write(*exchange)
+	if err == nil {
+		err = writeErr
+	}
+
+	return err
+}
+
+func (recorder *Recorder) write(exchange Exchange) error {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	recorder.exchanges = append(recorder.exchanges, exchange)
+
+	payload, err := json.Marshal(exchange)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(recorder.writer, "%s\n", payload)
+	return err
+}
+
+// ReadTranscript reads the exchanges written by a Recorder.
+func ReadTranscript(reader io.Reader) ([]Exchange, error) {
+	decoder := json.NewDecoder(reader)
+
+	var exchanges []Exchange
+	for {
+		var exchange Exchange
+		err := decoder.Decode(&exchange)
+		if err == io.EOF {
+			return exchanges, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		exchanges = append(exchanges, exchange)
+	}
+}
+
+// Replayer replays a transcript, answering each interaction resolved with it
+// with the input that was recorded, e.g. to turn a real session into a
+// regression test.
+//
+// Each interaction must have the same prompt as the next recorded exchange.
+// Redacted passwords cannot be replayed, and result in ErrRedacted.
+type Replayer struct {
+	mutex     sync.Mutex
+	exchanges []Exchange
+}
+
+// NewReplayer constructs a replayer for the given exchanges.
+func NewReplayer(exchanges []Exchange) *Replayer {
+	return &Replayer{exchanges: exchanges}
+}
+
+// Remaining returns the number of exchanges which have not been replayed.
+func (replayer *Replayer) Remaining() int {
+	replayer.mutex.Lock()
+	defer replayer.mutex.Unlock()
+
+	return len(replayer.exchanges)
+}
+
+// replay resolves dst with the input from the next exchange.
+func (replayer *Replayer) replay(interaction Interaction, dst interface{}) error {
+	exchange, err := replayer.next(interaction.Prompt)
+	if err != nil {
+		return err
+	}
+
+	user := &replayUser{
+		nonTTYUser: newNonTTYUser(nil, interaction.Output),
+		attempts:   exchange.Attempts,
+	}
+	user.back = interaction.back
+
+	return interaction.resolveWith(dst, user, interaction.prompt(dst))
+}
+
+func (replayer *Replayer) next(prompt string) (Exchange, error) {
+	replayer.mutex.Lock()
+	defer replayer.mutex.Unlock()
+
+	if len(replayer.exchanges) == 0 {
+		return Exchange{}, ErrTranscriptEnded
+	}
+
+	exchange := replayer.exchanges[0]
+	if exchange.Prompt != prompt {
+		return Exchange{}, fmt.Errorf("transcript out of sync: expected %q, got %q", exchange.Prompt, prompt)
+	}
+
+	replayer.exchanges = replayer.exchanges[1:]
+
+	return exchange, nil
+}
+
+// recordingUser records each line read from the user, and any message
+// telling them why it was not accepted.
+type recordingUser struct {
+	userIO
+
+	exchange *Exchange
+	back     string
+}
+
+func (u *recordingUser) WriteLine(line string) error {
+	if n := len(u.exchange.Attempts); n > 0 && u.exchange.Attempts[n-1].Retry == "" {
+		u.exchange.Attempts[n-1].Retry = line
+	}
+
+	return u.userIO.WriteLine(line)
+}
+
+func (u *recordingUser) ReadLine(prompt string) (string, error) {
+	line, err := u.userIO.ReadLine(prompt)
+	u.attempt(line, false, err)
+	return line, err
+}
+
+func (u *recordingUser) ReadPassword(prompt string) (string, error) {
+	pass, err := u.userIO.ReadPassword(prompt)
+	u.attempt("", true, err)
+	return pass, err
+}
+
+func (u *recordingUser) ReadLines(prompt string) (string, error) {
+	text, err := u.userIO.ReadLines(prompt)
+	u.attempt(text, false, err)
+	return text, err
+}
+
+func (u *recordingUser) attempt(input string, redacted bool, err error) {
+	if errors.Is(err, errBack) {
+		input, redacted = u.back, false
+	} else if err != nil {
+		return
+	}
+
+	u.exchange.Attempts = append(u.exchange.Attempts, Attempt{
+		Time:     time.Now(),
+		Input:    input,
+		Redacted: redacted,
+	})
+}
+
+// recordingSelector records menu selections as the numbers the user would
+// have entered.
+type recordingSelector struct {
+	*recordingUser
+
+	menu selector
+}
+
+func (u recordingSelector) Select(prompt string, options []string, initial int) (int, error) {
+	idx, err := u.menu.Select(prompt, options, initial)
+	u.attempt(strconv.Itoa(idx+1), false, err)
+	return idx, err
+}
+
+func (u recordingSelector) SelectMultiple(prompt string, options []string, selected []int) ([]int, error) {
+	idxs, err := u.menu.SelectMultiple(prompt, options, selected)
+
+	nums := make([]int, len(idxs))
+	for i, idx := range idxs {
+		nums[i] = idx + 1
+	}
+
+	u.attempt(formatSelection(nums), false, err)
+
+	return idxs, err
+}
+
+func (u recordingSelector) Edit(prompt string, editor Editor, text string) (string, error) {
+	user, ok := u.menu.(textEditor)
+	if !ok {
+		return u.recordingUser.ReadLines(prompt)
+	}
+
+	edited, err := user.Edit(prompt, editor, text)
+	u.attempt(edited, false, err)
+	return edited, err
+}
+
+// replayUser answers with recorded input.
+type replayUser struct {
+	nonTTYUser
+
+	attempts []Attempt
+}
+
+func (u *replayUser) ReadLine(prompt string) (string, error) {
+	attempt, err := u.next(prompt)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = fmt.Fprintf(u.Writer, "%s\n", attempt.Input)
+	if err != nil {
+		return "", err
+	}
+
+	return checkBack(attempt.Input, u.back)
+}
+
+func (u *replayUser) ReadPassword(prompt string) (string, error) {
+	attempt, err := u.next(prompt)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = fmt.Fprintf(u.Writer, "\n")
+	if err != nil {
+		return "", err
+	}
+
+	return checkBack(attempt.Input, u.back)
+}
+
+func (u *replayUser) ReadLines(prompt string) (string, error) {
+	attempt, err := u.next(prompt + "\n")
+	if err != nil {
+		return "", err
+	}
+
+	if attempt.Input != "" {
+		_, err = fmt.Fprintf(u.Writer, "%s\n", attempt.Input)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return checkBack(attempt.Input, u.back)
+}
+
+// next shows the prompt and returns the next attempt, or io.EOF if there are
+// none left, as the recorded session must have ended.
+func (u *replayUser) next(prompt string) (Attempt, error) {
+	_, err := fmt.Fprintf(u.Writer, "%s", prompt)
+	if err != nil {
+		return Attempt{}, err
+	}
+
+	if len(u.attempts) == 0 {
+		return Attempt{}, io.EOF
+	}
+
+	attempt := u.attempts[0]
+	u.attempts = u.attempts[1:]
+
+	if attempt.Redacted {
+		return Attempt{}, ErrRedacted
+	}
+
+	return attempt, nil
+}
@@ -0,0 +1,184 @@
+package interact_test
+
+import (
+	"bytes"
+	"io"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+
+	"github.com/vito/go-interact/interact"
+	"github.com/vito/go-interact/interact/interacttest"
+)
+
+var _ = Describe("Recording and replaying", func() {
+	var (
+		transcript *bytes.Buffer
+		recorder   *interact.Recorder
+
+		output *gbytes.Buffer
+	)
+
+	BeforeEach(func() {
+		transcript = new(bytes.Buffer)
+		recorder = interact.NewRecorder(transcript)
+
+		output = gbytes.NewBuffer()
+	})
+
+	record := func(input string, interaction interact.Interaction, dst interface{}) error {
+		interaction.Input = bytes.NewBufferString(input)
+		interaction.Output = output
+		interaction.Recorder = recorder
+		return interaction.Resolve(dst)
+	}
+
+	It("records each attempt, and why it was retried", func() {
+		port := 80
+		Expect(record("http\n8080\n", interact.NewInteraction("Port"), &port)).To(Succeed())
+		Expect(port).To(Equal(8080))
+
+		exchanges := recorder.Exchanges()
+		Expect(exchanges).To(HaveLen(1))
+
+		exchange := exchanges[0]
+		Expect(exchange.Prompt).To(Equal("Port"))
+		Expect(exchange.Time).ToNot(BeZero())
+		Expect(exchange.Value).To(Equal("8080"))
+		Expect(exchange.Error).To(BeEmpty())
+
+		Expect(exchange.Attempts).To(HaveLen(2))
+		Expect(exchange.Attempts[0].Input).To(Equal("http"))
+		Expect(exchange.Attempts[0].Retry).To(Equal("invalid input (not a number)"))
+		Expect(exchange.Attempts[0].Time).ToNot(BeZero())
+		Expect(exchange.Attempts[1].Input).To(Equal("8080"))
+		Expect(exchange.Attempts[1].Retry).To(BeEmpty())
+	})
+
+	It("records the displayed choices", func() {
+		interaction := interact.NewInteraction("Number",
+			interact.Choice{Display: "Uno", Value: "uno"},
+			interact.Choice{Display: "Dos", Value: "dos"},
+		)
+		interaction.Name = "number"
+
+		var number string
+		Expect(record("2\n", interaction, &number)).To(Succeed())
+
+		exchange := recorder.Exchanges()[0]
+		Expect(exchange.Name).To(Equal("number"))
+		Expect(exchange.Choices).To(Equal([]string{"Uno", "Dos"}))
+		Expect(exchange.Attempts[0].Input).To(Equal("2"))
+		Expect(exchange.Value).To(Equal("Dos"))
+	})
+
+	It("records the error that ended the interaction", func() {
+		var name string
+		Expect(record("", interact.NewInteraction("Name"), &name)).To(Equal(io.EOF))
+
+		exchange := recorder.Exchanges()[0]
+		Expect(exchange.Attempts).To(BeEmpty())
+		Expect(exchange.Error).To(Equal("EOF"))
+	})
+
+	It("redacts passwords", func() {
+		var password interact.Password
+		Expect(record("hunter2\n", interact.NewInteraction("Password"), &password)).To(Succeed())
+
+		exchange := recorder.Exchanges()[0]
+		Expect(exchange.Attempts[0].Redacted).To(BeTrue())
+		Expect(exchange.Attempts[0].Input).To(BeEmpty())
+		Expect(exchange.Value).To(Equal("********"))
+
+		Expect(transcript.String()).ToNot(ContainSubstring("hunter2"))
+	})
+
+	It("records menu selections as choice numbers", func() {
+		term := interacttest.NewTerminal(GinkgoT())
+
+		interaction := term.Interaction("Number",
+			interact.Choice{Display: "Uno", Value: "uno"},
+			interact.Choice{Display: "Dos", Value: "dos"},
+		)
+		interaction.Recorder = recorder
+
+		var number string
+		term.Start(func() error {
+			return interaction.Resolve(&number)
+		})
+
+		term.ExpectScreen("> Uno")
+		term.Press(interacttest.KeyDown, interacttest.KeyEnter)
+		Expect(term.Wait()).To(Succeed())
+
+		exchange := recorder.Exchanges()[0]
+		Expect(exchange.Attempts[0].Input).To(Equal("2"))
+		Expect(exchange.Value).To(Equal("Dos"))
+	})
+
+	Describe("replaying", func() {
+		var name string
+		var port int
+
+		BeforeEach(func() {
+			name = ""
+			port = 80
+
+			Expect(record("alice\n", interact.NewInteraction("Name"), interact.Required(&name))).To(Succeed())
+			Expect(record("http\n8080\n", interact.NewInteraction("Port"), &port)).To(Succeed())
+		})
+
+		replay := func(replayer *interact.Replayer, prompt string, dst interface{}) error {
+			interaction := interact.NewInteraction(prompt)
+			interaction.Input = new(errReader)
+			interaction.Output = output
+			interaction.Replayer = replayer
+			return interaction.Resolve(dst)
+		}
+
+		It("answers with the recorded input, showing the same output", func() {
+			exchanges, err := interact.ReadTranscript(transcript)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(exchanges).To(HaveLen(2))
+
+			recorded := string(output.Contents())
+			output = gbytes.NewBuffer()
+
+			replayer := interact.NewReplayer(exchanges)
+
+			var replayedName string
+			replayedPort := 80
+			Expect(replay(replayer, "Name", interact.Required(&replayedName))).To(Succeed())
+			Expect(replay(replayer, "Port", &replayedPort)).To(Succeed())
+
+			Expect(replayedName).To(Equal("alice"))
+			Expect(replayedPort).To(Equal(8080))
+			Expect(replayer.Remaining()).To(BeZero())
+
+			Expect(string(output.Contents())).To(Equal(recorded))
+		})
+
+		It("returns an error when the prompts do not match", func() {
+			replayer := interact.NewReplayer(recorder.Exchanges())
+
+			var other string
+			Expect(replay(replayer, "Other", &other)).To(MatchError(`transcript out of sync: expected "Name", got "Other"`))
+		})
+
+		It("returns an error when the transcript has ended", func() {
+			replayer := interact.NewReplayer(nil)
+
+			var other string
+			Expect(replay(replayer, "Other", &other)).To(Equal(interact.ErrTranscriptEnded))
+		})
+
+		It("cannot replay redacted passwords", func() {
+			var password interact.Password
+			Expect(record("hunter2\n", interact.NewInteraction("Password"), &password)).To(Succeed())
+
+			replayer := interact.NewReplayer(recorder.Exchanges()[2:])
+			Expect(replay(replayer, "Password", &password)).To(Equal(interact.ErrRedacted))
+		})
+	})
+})
@@ -0,0 +1,17 @@
+package interact
+
+// Resolver can be implemented by a destination to control how it is parsed
+// from the user's input and how its current value is shown as the default.
+//
+// This allows arbitrary types (e.g. URLs, versions, or IDs) to be passed to
+// Resolve directly, typically by implementing Resolver on a pointer receiver.
+type Resolver interface {
+	// UnmarshalInput parses the line entered by the user into the value. If
+	// it returns an error, the error is shown to the user and they are asked
+	// again.
+	UnmarshalInput(line string) error
+
+	// MarshalInput returns the current value as it should be shown in the
+	// prompt as the default.
+	MarshalInput() string
+}
@@ -0,0 +1,129 @@
+package interact_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/vito/go-interact/interact"
+)
+
+var _ = Describe("Resolving into Resolvers", func() {
+	Context("when the destination has a value", func() {
+		BeforeEach(func() {
+			destination = versionDst(version{1, 2})
+		})
+
+		DescribeTable("Resolve", (Example).Run,
+			Entry("when a valid value is entered", Example{
+				Prompt: "some prompt",
+
+				Input: "3.4\n",
+
+				ExpectedAnswer: version{3, 4},
+				ExpectedOutput: "some prompt (1.2): 3.4\n",
+			}),
+
+			Entry("when a blank line is entered", Example{
+				Prompt: "some prompt",
+
+				Input: "\n",
+
+				ExpectedAnswer: version{1, 2},
+				ExpectedOutput: "some prompt (1.2): \n",
+			}),
+
+			Entry("when an invalid value is entered, followed by EOF", Example{
+				Prompt: "some prompt",
+
+				Input: "foo\n",
+
+				ExpectedAnswer: version{1, 2},
+				ExpectedErr:    io.EOF,
+				ExpectedOutput: "some prompt (1.2): foo\ninvalid input (not a version)\nsome prompt (1.2): ",
+			}),
+
+			Entry("when an invalid value is entered, followed by a valid value", Example{
+				Prompt: "some prompt",
+
+				Input: "foo\n3.4\n",
+
+				ExpectedAnswer: version{3, 4},
+				ExpectedOutput: "some prompt (1.2): foo\ninvalid input (not a version)\nsome prompt (1.2): 3.4\n",
+			}),
+		)
+
+		Context("when required", func() {
+			BeforeEach(func() {
+				destination = interact.Required(destination)
+			})
+
+			DescribeTable("Resolve", (Example).Run,
+				Entry("when a valid value is entered", Example{
+					Prompt: "some prompt",
+
+					Input: "3.4\n",
+
+					ExpectedAnswer: version{3, 4},
+					ExpectedOutput: "some prompt: 3.4\n",
+				}),
+
+				Entry("when a blank line is entered, followed by EOF", Example{
+					Prompt: "some prompt",
+
+					Input: "\n",
+
+					ExpectedAnswer: version{1, 2},
+					ExpectedErr:    io.EOF,
+					ExpectedOutput: "some prompt: \nsome prompt: ",
+				}),
+
+				Entry("when a blank line is entered, followed by a valid value", Example{
+					Prompt: "some prompt",
+
+					Input: "\n3.4\n",
+
+					ExpectedAnswer: version{3, 4},
+					ExpectedOutput: "some prompt: \nsome prompt: 3.4\n",
+				}),
+			)
+		})
+	})
+})
+
+type version struct {
+	major, minor int
+}
+
+var errNotAVersion = errors.New("not a version")
+
+func (v *version) UnmarshalInput(line string) error {
+	major, minor, found := strings.Cut(line, ".")
+	if !found {
+		return errNotAVersion
+	}
+
+	var err error
+	v.major, err = strconv.Atoi(major)
+	if err != nil {
+		return errNotAVersion
+	}
+
+	v.minor, err = strconv.Atoi(minor)
+	if err != nil {
+		return errNotAVersion
+	}
+
+	return nil
+}
+
+func (v *version) MarshalInput() string {
+	return fmt.Sprintf("%d.%d", v.major, v.minor)
+}
+
+func versionDst(dst version) *version {
+	return &dst
+}
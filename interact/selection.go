@@ -0,0 +1,226 @@
+package interact
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// isMultipleChoice returns true if dst is a pointer to a slice, and the
+// choices' values are elements of that slice, rather than slices themselves.
+func (interaction Interaction) isMultipleChoice(dst interface{}) bool {
+	dstType := reflect.TypeOf(dst)
+	if dstType == nil || dstType.Kind() != reflect.Ptr || dstType.Elem().Kind() != reflect.Slice {
+		return false
+	}
+
+	sliceType := dstType.Elem()
+
+	hasValues := false
+	for _, c := range interaction.Choices {
+		if c.Value == nil {
+			continue
+		}
+
+		valType := reflect.TypeOf(c.Value)
+		if valType.AssignableTo(sliceType) || !valType.AssignableTo(sliceType.Elem()) {
+			return false
+		}
+
+		hasValues = true
+	}
+
+	return hasValues
+}
+
+// choiceNumbers returns the numbers corresponding to each value in the slice
+// held in dst. If any value is not present in the choices, or dst is a
+// RequiredDestination, there is no default.
+func (interaction Interaction) choiceNumbers(dst interface{}) ([]int, bool) {
+	if _, ok := dst.(RequiredDestination); ok {
+		return nil, false
+	}
+
+	sliceVal := reflect.ValueOf(dst).Elem()
+
+	nums := []int{}
+	for i := 0; i < sliceVal.Len(); i++ {
+		num, found := interaction.choiceNumberOf(sliceVal.Index(i))
+		if !found {
+			return nil, false
+		}
+
+		nums = append(nums, num)
+	}
+
+	return nums, true
+}
+
+func (interaction Interaction) resolveMultipleChoices(dst interface{}, user userIO, prompt string) error {
+	dstVal := reflect.ValueOf(dst)
+
+	required, isRequired := dst.(RequiredDestination)
+	if isRequired {
+		dstVal = reflect.ValueOf(required.Destination)
+	}
+
+	sliceType := dstVal.Type().Elem()
+
+	menu, isMenu := user.(selector)
+	if !isMenu {
+		err := interaction.listChoices(user)
+		if err != nil {
+			return err
+		}
+	}
+
+	for {
+		var nums []int
+		var keep bool
+		var err error
+		if isMenu {
+			nums, err = interaction.selectChoices(dst, menu)
+		} else {
+			nums, keep, err = interaction.readChoices(dst, user, prompt)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		chosen := dstVal.Elem()
+
+		if !keep {
+			if nums == nil || (isRequired && len(nums) == 0) {
+				continue
+			}
+
+			chosen = interaction.choiceValues(sliceType, nums)
+		}
+
+		if interaction.Validate != nil {
+			err := interaction.Validate(chosen.Interface())
+			if err != nil {
+				user.WriteLine(fmt.Sprintf("invalid selection (%s)", err))
+				continue
+			}
+		}
+
+		dstVal.Elem().Set(chosen)
+
+		return nil
+	}
+}
+
+// choiceValues returns a slice of the given type containing the values of the
+// given choice numbers.
+func (interaction Interaction) choiceValues(sliceType reflect.Type, nums []int) reflect.Value {
+	chosen := reflect.MakeSlice(sliceType, 0, len(nums))
+	for _, num := range nums {
+		choice := interaction.Choices[num-1]
+
+		if choice.Value == nil {
+			chosen = reflect.Append(chosen, reflect.Zero(sliceType.Elem()))
+		} else {
+			chosen = reflect.Append(chosen, reflect.ValueOf(choice.Value))
+		}
+	}
+
+	return chosen
+}
+
+// readChoices asks the user to enter a selection of choices. If they enter
+// nothing and there is a default, keep is true. If the input is not valid, it
+// tells the user why and returns nil so they can be asked again.
+func (interaction Interaction) readChoices(dst interface{}, user userIO, prompt string) ([]int, bool, error) {
+	line, err := user.ReadLine(prompt)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if len(line) == 0 {
+		_, present := interaction.choiceNumbers(dst)
+		return nil, present, nil
+	}
+
+	nums, err := parseSelection(line, len(interaction.Choices))
+	if err != nil {
+		user.WriteLine(fmt.Sprintf("invalid selection (%s)", err))
+		return nil, false, nil
+	}
+
+	return nums, false, nil
+}
+
+// selectChoices presents the choices as a menu, with the default choices
+// initially selected.
+func (interaction Interaction) selectChoices(dst interface{}, menu selector) ([]int, error) {
+	defaults, _ := interaction.choiceNumbers(dst)
+
+	selected := make([]int, len(defaults))
+	for i, num := range defaults {
+		selected[i] = num - 1
+	}
+
+	idxs, err := menu.SelectMultiple(interaction.Prompt, interaction.choiceDisplays(), selected)
+	if err != nil {
+		return nil, err
+	}
+
+	nums := make([]int, len(idxs))
+	for i, idx := range idxs {
+		nums[i] = idx + 1
+	}
+
+	return nums, nil
+}
+
+// parseSelection parses a comma-separated list of choice numbers and ranges,
+// e.g. "1,3" or "2-4", each of which must be between 1 and max. Duplicates are
+// ignored.
+func parseSelection(line string, max int) ([]int, error) {
+	nums := []int{}
+	seen := map[int]bool{}
+
+	for _, part := range strings.Split(line, ",") {
+		part = strings.TrimSpace(part)
+
+		from, to := part, part
+		if before, after, isRange := strings.Cut(part, "-"); isRange {
+			from, to = strings.TrimSpace(before), strings.TrimSpace(after)
+		}
+
+		start, err := strconv.Atoi(from)
+		if err != nil {
+			return nil, ErrNotANumber
+		}
+
+		end, err := strconv.Atoi(to)
+		if err != nil {
+			return nil, ErrNotANumber
+		}
+
+		if start < 1 || end > max || start > end {
+			return nil, fmt.Errorf("must be 1-%d", max)
+		}
+
+		for num := start; num <= end; num++ {
+			if !seen[num] {
+				seen[num] = true
+				nums = append(nums, num)
+			}
+		}
+	}
+
+	return nums, nil
+}
+
+func formatSelection(nums []int) string {
+	strs := make([]string, len(nums))
+	for i, num := range nums {
+		strs[i] = strconv.Itoa(num)
+	}
+
+	return strings.Join(strs, ",")
+}
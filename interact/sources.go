@@ -0,0 +1,135 @@
+package interact
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Source provides a value for an interaction that was given explicitly some
+// other way, e.g. by an environment variable or command-line flag, so that the
+// user only needs to be asked for what's missing.
+type Source interface {
+	// Lookup returns the value, or false if none was given.
+	Lookup() (string, bool)
+
+	// String describes the source to the user, e.g. "$PORT" or "-port".
+	String() string
+}
+
+// Env returns a Source for the named environment variable. The variable
+// counts as given if it is set, even if it is empty.
+func Env(name string) Source {
+	return SourceFunc("$"+name, func() (string, bool) {
+		return os.LookupEnv(name)
+	})
+}
+
+// Flag returns a Source for the named flag in the given flag set. The flag
+// counts as given only if it was explicitly set on the command line; its
+// default is not used.
+func Flag(flags *flag.FlagSet, name string) Source {
+	return SourceFunc("-"+name, func() (string, bool) {
+		var value string
+		var given bool
+
+		flags.Visit(func(f *flag.Flag) {
+			if f.Name == name {
+				value = f.Value.String()
+				given = true
+			}
+		})
+
+		return value, given
+	})
+}
+
+// SourceFunc returns a Source described by desc which calls lookup, e.g. to
+// use flags from another package, such as pflag:
+//
+//	interact.SourceFunc("--port", func() (string, bool) {
+//		if !flags.Changed("port") {
+//			return "", false
+//		}
+//
+//		return flags.Lookup("port").Value.String(), true
+//	})
+func SourceFunc(desc string, lookup func() (string, bool)) Source {
+	return sourceFunc{desc: desc, lookup: lookup}
+}
+
+type sourceFunc struct {
+	desc   string
+	lookup func() (string, bool)
+}
+
+func (source sourceFunc) Lookup() (string, bool) {
+	return source.lookup()
+}
+
+func (source sourceFunc) String() string {
+	return source.desc
+}
+
+// MissingValueError is returned by Resolve when none of an interaction's
+// Sources were given, and the user cannot be asked because the input is not a
+// terminal. It wraps ErrNotInteractive.
+type MissingValueError struct {
+	Prompt  string
+	Sources []Source
+}
+
+func (err MissingValueError) Error() string {
+	descs := make([]string, len(err.Sources))
+	for i, source := range err.Sources {
+		descs[i] = source.String()
+	}
+
+	return fmt.Sprintf("no value given for %q (set %s)", err.Prompt, strings.Join(descs, " or "))
+}
+
+// Unwrap returns ErrNotInteractive.
+func (err MissingValueError) Unwrap() error {
+	return ErrNotInteractive
+}
+
+// lookupSource returns the value of the first of the interaction's Sources
+// that was given.
+func (interaction Interaction) lookupSource() (string, Source, bool) {
+	for _, source := range interaction.Sources {
+		if value, found := source.Lookup(); found {
+			return value, source, true
+		}
+	}
+
+	return "", nil, false
+}
+
+// resolveSource resolves dst from a value given by a source, as if the user
+// had entered it. Multiple choices are separated by commas, and bools may
+// also be given as accepted by strconv.ParseBool, as bool flags are.
+func (interaction Interaction) resolveSource(dst interface{}, source Source, value string) error {
+	var answer interface{} = value
+
+	choiceDst := dst
+	if required, ok := dst.(RequiredDestination); ok {
+		choiceDst = required.Destination
+	}
+
+	if interaction.isMultipleChoice(choiceDst) {
+		var list []interface{}
+		for _, choice := range strings.Split(value, ",") {
+			list = append(list, strings.TrimSpace(choice))
+		}
+
+		answer = list
+	}
+
+	err := interaction.resolveAnswerLines(dst, answer)
+	if err != nil {
+		return fmt.Errorf("%s: %w", source, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,191 @@
+package interact_test
+
+import (
+	"flag"
+	"io"
+
+	"github.com/kr/pty"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+
+	"github.com/vito/go-interact/interact"
+)
+
+var _ = Describe("Resolving from sources", func() {
+	var (
+		output *gbytes.Buffer
+		flags  *flag.FlagSet
+
+		interaction interact.Interaction
+	)
+
+	BeforeEach(func() {
+		output = gbytes.NewBuffer()
+
+		flags = flag.NewFlagSet("test", flag.ContinueOnError)
+		flags.Int("port", 80, "port to listen on")
+
+		interaction = interact.NewInteraction("Port")
+		interaction.Input = new(errReader)
+		interaction.Output = output
+		interaction.Sources = []interact.Source{
+			interact.Flag(flags, "port"),
+			interact.Env("INTERACT_TEST_PORT"),
+		}
+	})
+
+	It("uses a flag that was given, without prompting", func() {
+		Expect(flags.Parse([]string{"-port", "8080"})).To(Succeed())
+
+		var port int
+		Expect(interaction.Resolve(&port)).To(Succeed())
+		Expect(port).To(Equal(8080))
+
+		Expect(output.Contents()).To(BeEmpty())
+	})
+
+	It("accepts bools as given by bool flags", func() {
+		flags.Bool("tls", false, "enable TLS")
+		Expect(flags.Parse([]string{"-tls"})).To(Succeed())
+
+		interaction.Sources = []interact.Source{interact.Flag(flags, "tls")}
+
+		var enableTLS bool
+		Expect(interaction.Resolve(&enableTLS)).To(Succeed())
+		Expect(enableTLS).To(BeTrue())
+	})
+
+	It("accepts bools as usually given by environment variables", func() {
+		interaction.Sources = []interact.Source{interact.Env("INTERACT_TEST_TLS")}
+
+		GinkgoT().Setenv("INTERACT_TEST_TLS", "true")
+
+		var enableTLS bool
+		Expect(interaction.Resolve(interact.Required(&enableTLS))).To(Succeed())
+		Expect(enableTLS).To(BeTrue())
+
+		GinkgoT().Setenv("INTERACT_TEST_TLS", "0")
+
+		Expect(interaction.Resolve(&enableTLS)).To(Succeed())
+		Expect(enableTLS).To(BeFalse())
+
+		GinkgoT().Setenv("INTERACT_TEST_TLS", "yes")
+
+		Expect(interaction.Resolve(&enableTLS)).To(Succeed())
+		Expect(enableTLS).To(BeTrue())
+	})
+
+	It("prefers earlier sources", func() {
+		GinkgoT().Setenv("INTERACT_TEST_PORT", "9090")
+		Expect(flags.Parse([]string{"-port", "8080"})).To(Succeed())
+
+		var port int
+		Expect(interaction.Resolve(&port)).To(Succeed())
+		Expect(port).To(Equal(8080))
+	})
+
+	It("uses an environment variable that is set", func() {
+		GinkgoT().Setenv("INTERACT_TEST_PORT", "9090")
+		Expect(flags.Parse(nil)).To(Succeed())
+
+		var port int
+		Expect(interaction.Resolve(&port)).To(Succeed())
+		Expect(port).To(Equal(9090))
+	})
+
+	It("returns an error naming the source when the value is invalid", func() {
+		GinkgoT().Setenv("INTERACT_TEST_PORT", "http")
+
+		port := 80
+		err := interaction.Resolve(&port)
+		Expect(err).To(MatchError(interact.ErrNotANumber))
+		Expect(err).To(MatchError("$INTERACT_TEST_PORT: not a number"))
+		Expect(port).To(Equal(80))
+	})
+
+	It("splits multiple choices on commas", func() {
+		GinkgoT().Setenv("INTERACT_TEST_ZONES", "One, 3")
+
+		interaction.Choices = []interact.Choice{
+			{Display: "One", Value: 1},
+			{Display: "Two", Value: 2},
+			{Display: "Three", Value: 3},
+		}
+		interaction.Sources = []interact.Source{interact.Env("INTERACT_TEST_ZONES")}
+
+		var zones []int
+		Expect(interaction.Resolve(&zones)).To(Succeed())
+		Expect(zones).To(Equal([]int{1, 3}))
+	})
+
+	It("splits required multiple choices on commas", func() {
+		GinkgoT().Setenv("INTERACT_TEST_ZONES", "One,Three")
+
+		interaction.Choices = []interact.Choice{
+			{Display: "One", Value: 1},
+			{Display: "Two", Value: 2},
+			{Display: "Three", Value: 3},
+		}
+		interaction.Sources = []interact.Source{interact.Env("INTERACT_TEST_ZONES")}
+
+		var zones []int
+		Expect(interaction.Resolve(interact.Required(&zones))).To(Succeed())
+		Expect(zones).To(Equal([]int{1, 3}))
+	})
+
+	Context("when no source was given", func() {
+		It("returns an error if the input is not a terminal", func() {
+			var port int
+			err := interaction.Resolve(&port)
+			Expect(err).To(Equal(interact.MissingValueError{
+				Prompt:  "Port",
+				Sources: interaction.Sources,
+			}))
+			Expect(err).To(MatchError(`no value given for "Port" (set -port or $INTERACT_TEST_PORT)`))
+		})
+
+		It("prompts if the input is a terminal", func() {
+			aPty, tty, err := pty.Open()
+			Expect(err).NotTo(HaveOccurred())
+
+			DeferCleanup(aPty.Close)
+			DeferCleanup(tty.Close)
+
+			Expect(pty.Setsize(tty, &pty.Winsize{Rows: 24, Cols: 80})).To(Succeed())
+
+			go io.Copy(output, aPty)
+
+			interaction.Input = tty
+			interaction.Output = tty
+
+			errs := make(chan error, 1)
+			var port int
+			go func() {
+				errs <- interaction.Resolve(&port)
+			}()
+
+			Eventually(output).Should(gbytes.Say(`Port \(0\): `))
+
+			_, err = aPty.Write([]byte("8080\r"))
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(errs).Should(Receive(BeNil()))
+			Expect(port).To(Equal(8080))
+		})
+	})
+
+	Describe("struct fields", func() {
+		It("uses the environment variable named by the env tag", func() {
+			GinkgoT().Setenv("INTERACT_TEST_HOST", "db.example.com")
+
+			var config struct {
+				Host string `interact:"prompt=Host,env=INTERACT_TEST_HOST"`
+			}
+
+			err := interact.ResolveStruct(&config, interact.WithInput(new(errReader)), interact.WithOutput(output))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(config.Host).To(Equal("db.example.com"))
+		})
+	})
+})
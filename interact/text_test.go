@@ -0,0 +1,223 @@
+package interact_test
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/vito/go-interact/interact"
+)
+
+var _ = Describe("Resolving into TextUnmarshalers", func() {
+	Context("when the destination is a TextMarshaler", func() {
+		BeforeEach(func() {
+			destination = ipDst(net.ParseIP("10.0.0.1"))
+		})
+
+		DescribeTable("Resolve", (Example).Run,
+			Entry("when a valid value is entered", Example{
+				Prompt: "some prompt",
+
+				Input: "192.168.0.1\n",
+
+				ExpectedAnswer: net.ParseIP("192.168.0.1"),
+				ExpectedOutput: "some prompt (10.0.0.1): 192.168.0.1\n",
+			}),
+
+			Entry("when a blank line is entered", Example{
+				Prompt: "some prompt",
+
+				Input: "\n",
+
+				ExpectedAnswer: net.ParseIP("10.0.0.1"),
+				ExpectedOutput: "some prompt (10.0.0.1): \n",
+			}),
+
+			Entry("when an invalid value is entered, followed by EOF", Example{
+				Prompt: "some prompt",
+
+				Input: "foo\n",
+
+				ExpectedAnswer: net.ParseIP("10.0.0.1"),
+				ExpectedErr:    io.EOF,
+				ExpectedOutput: "some prompt (10.0.0.1): foo\ninvalid input (invalid IP address: foo)\nsome prompt (10.0.0.1): ",
+			}),
+
+			Entry("when an invalid value is entered, followed by a valid value", Example{
+				Prompt: "some prompt",
+
+				Input: "foo\n192.168.0.1\n",
+
+				ExpectedAnswer: net.ParseIP("192.168.0.1"),
+				ExpectedOutput: "some prompt (10.0.0.1): foo\ninvalid input (invalid IP address: foo)\nsome prompt (10.0.0.1): 192.168.0.1\n",
+			}),
+		)
+
+		Context("when required", func() {
+			BeforeEach(func() {
+				destination = interact.Required(destination)
+			})
+
+			DescribeTable("Resolve", (Example).Run,
+				Entry("when a blank line is entered, followed by a valid value", Example{
+					Prompt: "some prompt",
+
+					Input: "\n192.168.0.1\n",
+
+					ExpectedAnswer: net.ParseIP("192.168.0.1"),
+					ExpectedOutput: "some prompt: \nsome prompt: 192.168.0.1\n",
+				}),
+			)
+		})
+	})
+
+	Context("when the destination is a big.Int", func() {
+		BeforeEach(func() {
+			destination = big.NewInt(42)
+		})
+
+		DescribeTable("Resolve", (Example).Run,
+			Entry("when a valid value is entered", Example{
+				Prompt: "some prompt",
+
+				Input: "123456789012345678901234567890\n",
+
+				ExpectedAnswer: *bigInt("123456789012345678901234567890"),
+				ExpectedOutput: "some prompt (42): 123456789012345678901234567890\n",
+			}),
+
+			Entry("when a blank line is entered", Example{
+				Prompt: "some prompt",
+
+				Input: "\n",
+
+				ExpectedAnswer: *big.NewInt(42),
+				ExpectedOutput: "some prompt (42): \n",
+			}),
+		)
+	})
+
+	Context("when the destination is a Stringer", func() {
+		BeforeEach(func() {
+			destination = colorDst(green)
+		})
+
+		DescribeTable("Resolve", (Example).Run,
+			Entry("when a valid value is entered", Example{
+				Prompt: "some prompt",
+
+				Input: "red\n",
+
+				ExpectedAnswer: red,
+				ExpectedOutput: "some prompt (green): red\n",
+			}),
+
+			Entry("when a blank line is entered", Example{
+				Prompt: "some prompt",
+
+				Input: "\n",
+
+				ExpectedAnswer: green,
+				ExpectedOutput: "some prompt (green): \n",
+			}),
+
+			Entry("when an invalid value is entered, followed by a valid value", Example{
+				Prompt: "some prompt",
+
+				Input: "blue\nred\n",
+
+				ExpectedAnswer: red,
+				ExpectedOutput: "some prompt (green): blue\ninvalid input (unknown color: blue)\nsome prompt (green): red\n",
+			}),
+		)
+	})
+
+	Context("when the destination cannot be formatted", func() {
+		BeforeEach(func() {
+			destination = opaqueDst(opaque{"secret"})
+		})
+
+		DescribeTable("Resolve", (Example).Run,
+			Entry("when a valid value is entered", Example{
+				Prompt: "some prompt",
+
+				Input: "other\n",
+
+				ExpectedAnswer: opaque{"other"},
+				ExpectedOutput: "some prompt: other\n",
+			}),
+
+			Entry("when a blank line is entered", Example{
+				Prompt: "some prompt",
+
+				Input: "\n",
+
+				ExpectedAnswer: opaque{"secret"},
+				ExpectedOutput: "some prompt: \n",
+			}),
+		)
+	})
+})
+
+func ipDst(dst net.IP) *net.IP {
+	return &dst
+}
+
+func bigInt(str string) *big.Int {
+	i, ok := new(big.Int).SetString(str, 10)
+	if !ok {
+		panic("invalid big.Int: " + str)
+	}
+
+	return i
+}
+
+type color int
+
+const (
+	red color = iota
+	green
+)
+
+func (c color) String() string {
+	switch c {
+	case red:
+		return "red"
+	case green:
+		return "green"
+	default:
+		return "unknown"
+	}
+}
+
+func (c *color) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "red":
+		*c = red
+	case "green":
+		*c = green
+	default:
+		return fmt.Errorf("unknown color: %s", text)
+	}
+
+	return nil
+}
+
+func colorDst(dst color) *color {
+	return &dst
+}
+
+type opaque struct {
+	value string
+}
+
+func (o *opaque) UnmarshalText(text []byte) error {
+	o.value = string(text)
+	return nil
+}
+
+func opaqueDst(dst opaque) *opaque {
+	return &dst
+}
@@ -0,0 +1,38 @@
+package interact
+
+import (
+	"strings"
+	"time"
+)
+
+// DefaultTimeLayout is the layout used for parsing and showing time.Time
+// values when the Interaction does not specify a TimeLayout.
+const DefaultTimeLayout = time.RFC3339
+
+func (interaction Interaction) timeLayout() string {
+	if interaction.TimeLayout == "" {
+		return DefaultTimeLayout
+	}
+
+	return interaction.TimeLayout
+}
+
+// parseTime parses the given line either in the given layout, or as a time
+// relative to now, e.g. "now", "+2h", "-30m", or "now+1h30m".
+func parseTime(line string, layout string, now time.Time) (time.Time, error) {
+	rel := strings.TrimPrefix(line, "now")
+	if rel == "" {
+		return now, nil
+	}
+
+	if strings.HasPrefix(rel, "+") || strings.HasPrefix(rel, "-") {
+		offset, err := time.ParseDuration(rel)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		return now.Add(offset), nil
+	}
+
+	return time.ParseInLocation(layout, line, time.Local)
+}
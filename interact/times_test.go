@@ -0,0 +1,126 @@
+package interact_test
+
+import (
+	"bytes"
+	"io"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/vito/go-interact/interact"
+)
+
+var _ = Describe("Resolving into durations", func() {
+	BeforeEach(func() {
+		destination = durationDst(5 * time.Minute)
+	})
+
+	DescribeTable("Resolve", (Example).Run,
+		Entry("when a duration is entered", Example{
+			Prompt: "some prompt",
+
+			Input: "1h30m\n",
+
+			ExpectedAnswer: 90 * time.Minute,
+			ExpectedOutput: "some prompt (5m0s): 1h30m\n",
+		}),
+
+		Entry("when a blank line is entered", Example{
+			Prompt: "some prompt",
+
+			Input: "\n",
+
+			ExpectedAnswer: 5 * time.Minute,
+			ExpectedOutput: "some prompt (5m0s): \n",
+		}),
+
+		Entry("when a non-duration is entered, followed by EOF", Example{
+			Prompt: "some prompt",
+
+			Input: "42\n",
+
+			ExpectedAnswer: 5 * time.Minute,
+			ExpectedErr:    io.EOF,
+			ExpectedOutput: "some prompt (5m0s): 42\ninvalid input (time: missing unit in duration \"42\")\nsome prompt (5m0s): ",
+		}),
+
+		Entry("when a non-duration is entered, followed by a duration", Example{
+			Prompt: "some prompt",
+
+			Input: "42\n10s\n",
+
+			ExpectedAnswer: 10 * time.Second,
+			ExpectedOutput: "some prompt (5m0s): 42\ninvalid input (time: missing unit in duration \"42\")\nsome prompt (5m0s): 10s\n",
+		}),
+	)
+})
+
+var _ = Describe("Resolving into times", func() {
+	BeforeEach(func() {
+		destination = timeDst(time.Date(2016, 1, 2, 3, 4, 5, 0, time.UTC))
+	})
+
+	DescribeTable("Resolve", (Example).Run,
+		Entry("when a time is entered", Example{
+			Prompt: "some prompt",
+
+			Input: "2017-06-07T08:09:10Z\n",
+
+			ExpectedAnswer: time.Date(2017, 6, 7, 8, 9, 10, 0, time.UTC),
+			ExpectedOutput: "some prompt (2016-01-02T03:04:05Z): 2017-06-07T08:09:10Z\n",
+		}),
+
+		Entry("when a blank line is entered", Example{
+			Prompt: "some prompt",
+
+			Input: "\n",
+
+			ExpectedAnswer: time.Date(2016, 1, 2, 3, 4, 5, 0, time.UTC),
+			ExpectedOutput: "some prompt (2016-01-02T03:04:05Z): \n",
+		}),
+
+		Entry("when a non-time is entered, followed by a time", Example{
+			Prompt: "some prompt",
+
+			Input: "tomorrow\n2017-06-07T08:09:10Z\n",
+
+			ExpectedAnswer: time.Date(2017, 6, 7, 8, 9, 10, 0, time.UTC),
+			ExpectedOutput: "some prompt (2016-01-02T03:04:05Z): tomorrow\ninvalid input (parsing time \"tomorrow\" as \"2006-01-02T15:04:05Z07:00\": cannot parse \"tomorrow\" as \"2006\")\nsome prompt (2016-01-02T03:04:05Z): 2017-06-07T08:09:10Z\n",
+		}),
+
+		Entry("when a time is entered in a custom layout", Example{
+			Prompt:     "some prompt",
+			TimeLayout: "2006-01-02 15:04 MST",
+
+			Input: "2017-06-07 08:09 UTC\n",
+
+			ExpectedAnswer: time.Date(2017, 6, 7, 8, 9, 0, 0, time.UTC),
+			ExpectedOutput: "some prompt (2016-01-02 03:04 UTC): 2017-06-07 08:09 UTC\n",
+		}),
+	)
+
+	DescribeTable("entering a relative time",
+		func(input string, offset time.Duration) {
+			interaction := interact.NewInteraction("some prompt")
+			interaction.Input = bytes.NewBufferString(input + "\n")
+			interaction.Output = gbytes.NewBuffer()
+
+			var answer time.Time
+			Expect(interaction.Resolve(&answer)).To(Succeed())
+			Expect(answer).To(BeTemporally("~", time.Now().Add(offset), time.Second))
+		},
+		Entry("now", "now", time.Duration(0)),
+		Entry("now, plus a duration", "now+2h", 2*time.Hour),
+		Entry("plus a duration", "+2h", 2*time.Hour),
+		Entry("minus a duration", "-30m", -30*time.Minute),
+	)
+})
+
+func durationDst(dst time.Duration) *time.Duration {
+	return &dst
+}
+
+func timeDst(dst time.Time) *time.Time {
+	return &dst
+}
@@ -1,9 +1,14 @@
 package interact
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"os"
+	"reflect"
+	"strings"
+	"sync"
 
 	"golang.org/x/term"
 )
@@ -13,28 +18,87 @@ type userIO interface {
 
 	ReadLine(prompt string) (string, error)
 	ReadPassword(prompt string) (string, error)
+
+	// ReadLines reads an answer which may span several lines.
+	ReadLines(prompt string) (string, error)
 }
 
 type ttyUser struct {
 	*term.Terminal
-}
 
-func newTTYUser(input io.Reader, output *os.File) (ttyUser, error) {
-	t := term.NewTerminal(readWriter{input, output}, "")
+	input  io.Reader
+	output io.Writer
+
+	width, height int
+
+	// back is the input that signals going back, if any
+	back string
+
+	// completion completes lines when Tab is pressed, if set
+	completion *completion
+
+	// history and recall let the user recall previous answers, if set
+	history    *History
+	historyKey string
+	recall     *recall
+
+	// answer is the last line read, which is added to the history once it
+	// has been accepted
+	answer *string
+
+	// prefill, if set, is the text the line starts out with, for the user to
+	// edit in place
+	prefill *string
 
+	keys *keyReader
+
+	// file and state are the terminal and its state before entering raw
+	// mode, restored while running an editor
+	file  *os.File
+	state *term.State
+}
+
+func newTTYUser(interaction Interaction, input io.Reader, output *os.File) (ttyUser, error) {
 	width, height, err := term.GetSize(int(output.Fd()))
 	if err != nil {
 		return ttyUser{}, err
 	}
 
-	err = t.SetSize(width, height)
+	user := ttyUser{
+		input:  input,
+		output: output,
+
+		width:  width,
+		height: height,
+
+		back: interaction.back,
+	}
+
+	user.keys = &keyReader{reader: input}
+
+	var rw io.ReadWriter = readWriter{user.keys, output}
+
+	if interaction.History != nil {
+		user.history = interaction.History
+		user.historyKey = interaction.historyKey()
+		user.recall = &recall{}
+		user.answer = new(string)
+		user.keys.recall = true
+	}
+
+	if interaction.Complete != nil {
+		user.completion = newCompletion(interaction.Complete, rw, rw, width, height)
+		rw = user.completion
+	}
+
+	user.Terminal = term.NewTerminal(rw, "")
+
+	err = user.Terminal.SetSize(width, height)
 	if err != nil {
 		return ttyUser{}, err
 	}
 
-	return ttyUser{
-		Terminal: t,
-	}, nil
+	return user, nil
 }
 
 func (u ttyUser) WriteLine(line string) error {
@@ -44,12 +108,192 @@ func (u ttyUser) WriteLine(line string) error {
 
 func (u ttyUser) ReadLine(prompt string) (string, error) {
 	u.Terminal.SetPrompt(prompt)
-	return u.Terminal.ReadLine()
+
+	u.Terminal.SetBracketedPasteMode(true)
+	defer u.Terminal.SetBracketedPasteMode(false)
+
+	if u.completion != nil {
+		u.completion.prompt = prompt
+	}
+
+	if u.recall != nil {
+		// a history that cannot be loaded just has nothing to recall; it is
+		// no reason to keep the user from answering
+		entries, _ := u.history.Entries(u.historyKey)
+		u.recall.reset(entries)
+	}
+
+	u.Terminal.AutoCompleteCallback = u.editLine
+	defer func() { u.Terminal.AutoCompleteCallback = nil }()
+
+	if u.prefill != nil && *u.prefill != "" {
+		u.keys.insert(keyPrefill)
+	}
+
+	line, err := u.Terminal.ReadLine()
+	if err != nil && err != term.ErrPasteIndicator {
+		return "", err
+	}
+
+	if u.completion != nil {
+		err = u.completion.clear()
+		if err != nil {
+			return "", err
+		}
+	}
+
+	line, err = checkBack(line, u.back)
+	if err != nil {
+		return "", err
+	}
+
+	if u.answer != nil {
+		*u.answer = line
+	}
+
+	if u.prefill != nil && line != "" {
+		// if the line is not accepted, let the user fix it rather than start
+		// over
+		*u.prefill = line
+	}
+
+	return line, nil
+}
+
+// keepAnswer adds the last line read to the history, if any, once the answer
+// has been accepted. The answer stands even if the history cannot be saved.
+func (u ttyUser) keepAnswer() {
+	if u.answer == nil || *u.answer == "" {
+		return
+	}
+
+	u.history.Add(u.historyKey, *u.answer)
+}
+
+// editLine handles the keys the terminal does not handle itself.
+func (u ttyUser) editLine(line string, pos int, key rune) (string, int, bool) {
+	if key == keyPrefill && u.prefill != nil {
+		return *u.prefill, len(*u.prefill), true
+	}
+
+	if u.recall != nil {
+		if newLine, newPos, ok := u.recall.callback(line, pos, key); ok {
+			return newLine, newPos, true
+		}
+	}
+
+	if u.completion != nil {
+		return u.completion.callback(line, pos, key)
+	}
+
+	return "", 0, false
+}
+
+func (u ttyUser) ReadPassword(prompt string) (string, error) {
+	u.Terminal.SetBracketedPasteMode(true)
+	defer u.Terminal.SetBracketedPasteMode(false)
+
+	if u.recall != nil {
+		u.Terminal.AutoCompleteCallback = ignoreRecall
+		defer func() { u.Terminal.AutoCompleteCallback = nil }()
+	}
+
+	pass, err := u.Terminal.ReadPassword(prompt)
+	if err != nil && err != term.ErrPasteIndicator {
+		return "", err
+	}
+
+	return checkBack(pass, u.back)
+}
+
+// ReadLines reads lines until the user presses Ctrl-D or Alt-Enter, returning
+// them joined by newlines.
+func (u ttyUser) ReadLines(prompt string) (string, error) {
+	_, err := fmt.Fprintf(u.Terminal, "%s%s(Ctrl-D to finish)%s\r\n", prompt, startFaint, resetStyle)
+	if err != nil {
+		return "", err
+	}
+
+	u.Terminal.SetPrompt("")
+
+	u.Terminal.SetBracketedPasteMode(true)
+	defer u.Terminal.SetBracketedPasteMode(false)
+
+	u.keys.finish = true
+	defer func() { u.keys.finish = false }()
+
+	finished := false
+
+	u.Terminal.AutoCompleteCallback = func(line string, pos int, key rune) (string, int, bool) {
+		if key == keyFinish {
+			finished = true
+			return line, pos, true
+		}
+
+		return ignoreRecall(line, pos, key)
+	}
+
+	defer func() { u.Terminal.AutoCompleteCallback = nil }()
+
+	var lines []string
+	for !finished {
+		line, err := u.Terminal.ReadLine()
+		if err != nil && err != term.ErrPasteIndicator {
+			return "", err
+		}
+
+		lines = append(lines, line)
+	}
+
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return checkBack(strings.Join(lines, "\n"), u.back)
+}
+
+func (u ttyUser) Select(prompt string, options []string, initial int) (int, error) {
+	m := newMenu(prompt, options, u.width, u.height)
+	m.back = u.back != ""
+	m.moveTo(initial)
+
+	err := m.run(u.input, u.output)
+	if err != nil {
+		return 0, err
+	}
+
+	option, _ := m.current()
+
+	return option, nil
+}
+
+func (u ttyUser) SelectMultiple(prompt string, options []string, selected []int) ([]int, error) {
+	m := newMenu(prompt, options, u.width, u.height)
+	m.back = u.back != ""
+	m.multiple = true
+
+	for _, idx := range selected {
+		m.selected[idx] = true
+	}
+
+	if len(selected) > 0 {
+		m.moveTo(selected[0])
+	}
+
+	err := m.run(u.input, u.output)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.chosen(), nil
 }
 
 type nonTTYUser struct {
 	io.Reader
 	io.Writer
+
+	// back is the input that signals going back, if any
+	back string
 }
 
 func newNonTTYUser(input io.Reader, output io.Writer) nonTTYUser {
@@ -80,7 +324,7 @@ func (u nonTTYUser) ReadLine(prompt string) (string, error) {
 		return "", err
 	}
 
-	return line, nil
+	return checkBack(line, u.back)
 }
 
 func (u nonTTYUser) ReadPassword(prompt string) (string, error) {
@@ -99,7 +343,41 @@ func (u nonTTYUser) ReadPassword(prompt string) (string, error) {
 		return "", err
 	}
 
-	return line, nil
+	return checkBack(line, u.back)
+}
+
+// ReadLines reads lines until one is MultilineEnd or the input ends,
+// returning them joined by newlines.
+func (u nonTTYUser) ReadLines(prompt string) (string, error) {
+	_, err := fmt.Fprintf(u.Writer, "%s\n", prompt)
+	if err != nil {
+		return "", err
+	}
+
+	var lines []string
+	for {
+		line, err := u.readLine()
+		if err == io.EOF && len(lines) > 0 {
+			break
+		}
+
+		if err != nil {
+			return "", err
+		}
+
+		if line == MultilineEnd {
+			break
+		}
+
+		lines = append(lines, line)
+
+		_, err = fmt.Fprintf(u.Writer, "%s\n", line)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return checkBack(strings.Join(lines, "\n"), u.back)
 }
 
 func (u nonTTYUser) readLine() (string, error) {
@@ -125,7 +403,302 @@ func (u nonTTYUser) readLine() (string, error) {
 	}
 }
 
+// checkBack returns errBack if the line is the input signaling going back.
+func checkBack(line string, back string) (string, error) {
+	if back != "" && line == back {
+		return "", errBack
+	}
+
+	return line, nil
+}
+
+// Keys which the terminal passes to its AutoCompleteCallback in place of keys
+// it would otherwise handle itself.
+const (
+	// keyRecallPrevious and keyRecallNext replace Up and Down, as the
+	// terminal's own history cannot be loaded
+	keyRecallPrevious = '\ue000'
+	keyRecallNext     = '\ue001'
+
+	// keyFinish replaces Ctrl-D and Alt-Enter when reading several lines,
+	// followed by Enter to end the current line
+	keyFinish = '\ue002'
+
+	// keyPrefill is inserted before reading a line which starts out with
+	// text, as the terminal's line cannot be set directly
+	keyPrefill = '\ue003'
+)
+
+// keyReader translates the keys which the terminal would otherwise handle
+// itself.
+type keyReader struct {
+	reader io.Reader
+
+	// recall is true if Up and Down should be translated
+	recall bool
+
+	// finish is true if Ctrl-D and Alt-Enter should be translated
+	finish bool
+
+	// partial is the start of what may be a sequence to translate
+	partial []byte
+
+	pending []byte
+	err     error
+}
+
+func (r *keyReader) Read(p []byte) (int, error) {
+	for len(r.pending) == 0 {
+		if r.err != nil {
+			err := r.err
+			r.err = nil
+			return 0, err
+		}
+
+		buf := make([]byte, len(p))
+		n, err := r.reader.Read(buf)
+
+		r.pending, r.partial = r.translate(append(r.partial, buf[:n]...))
+
+		if err != nil {
+			r.pending = append(r.pending, r.partial...)
+			r.partial = nil
+			r.err = err
+		}
+	}
+
+	n := copy(p, r.pending)
+	r.pending = r.pending[n:]
+
+	return n, nil
+}
+
+// insert inserts the given key before any further input.
+func (r *keyReader) insert(key rune) {
+	r.pending = append([]byte(string(key)), r.pending...)
+}
+
+// translate translates any keys in data, returning any incomplete sequence
+// at the end separately.
+func (r *keyReader) translate(data []byte) ([]byte, []byte) {
+	translations := map[string]string{}
+
+	if r.recall {
+		translations["\x1b[A"] = string(keyRecallPrevious)
+		translations["\x1bOA"] = string(keyRecallPrevious)
+		translations["\x1b[B"] = string(keyRecallNext)
+		translations["\x1bOB"] = string(keyRecallNext)
+	}
+
+	if r.finish {
+		translations["\x04"] = string(keyFinish) + "\r"
+		translations["\x1b\r"] = string(keyFinish) + "\r"
+	}
+
+	translated := make([]byte, 0, len(data))
+
+	for i := 0; i < len(data); i++ {
+		rest := data[i:]
+
+		matched := false
+		for seq, key := range translations {
+			if bytes.HasPrefix(rest, []byte(seq)) {
+				translated = append(translated, key...)
+				i += len(seq) - 1
+				matched = true
+				break
+			}
+		}
+
+		if matched {
+			continue
+		}
+
+		for seq := range translations {
+			if len(rest) < len(seq) && strings.HasPrefix(seq, string(rest)) {
+				return translated, append([]byte(nil), rest...)
+			}
+		}
+
+		translated = append(translated, data[i])
+	}
+
+	return translated, nil
+}
+
 type readWriter struct {
 	io.Reader
 	io.Writer
 }
+
+// contextReader reads from the underlying reader until the context is done,
+// after which all reads fail with the context's error, including any read
+// that was already pending.
+//
+// Files are waited on until there is input before reading, where possible,
+// so that nothing is left reading from them once the context is done. Reads
+// from anything else are left pending, and whatever they read goes to the
+// next read from the same reader rather than being lost, so long as there is
+// one before the interaction ends; see abandonRead.
+type contextReader struct {
+	ctx    context.Context
+	reader io.Reader
+}
+
+func newContextReader(ctx context.Context, reader io.Reader) contextReader {
+	// pick up any read abandoned by an earlier interaction straight away, so
+	// that nothing it reads from now on is dropped
+	pendingRead(reader)
+
+	return contextReader{
+		ctx:    ctx,
+		reader: reader,
+	}
+}
+
+func (r contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	read, found := pendingRead(r.reader)
+	if !found {
+		if r.ctx.Done() == nil {
+			// nothing is pending and nothing can cancel the read
+			return r.reader.Read(p)
+		}
+
+		if file, ok := r.reader.(*os.File); ok {
+			ready, err := waitForInput(r.ctx, file)
+			if err != nil {
+				return 0, err
+			}
+
+			if ready {
+				return file.Read(p)
+			}
+		}
+
+		read = startRead(r.reader, len(p))
+	}
+
+	select {
+	case <-r.ctx.Done():
+		return 0, r.ctx.Err()
+	case <-read.done:
+		return read.take(p)
+	}
+}
+
+// backgroundRead is a read from a reader running in the background, which is
+// kept until what it read has been taken.
+type backgroundRead struct {
+	reader io.Reader
+
+	// done is closed once the read has completed
+	done chan struct{}
+
+	buf []byte
+	err error
+
+	// finished is set once the read has completed
+	finished bool
+
+	// abandoned is set if the interaction which started the read ended
+	// before the read completed, and no other has picked it up since
+	abandoned bool
+}
+
+var (
+	// backgroundReads holds the background read of each reader, if any
+	backgroundReads      = map[io.Reader]*backgroundRead{}
+	backgroundReadsMutex sync.Mutex
+)
+
+// pendingRead returns the background read of the given reader, if any,
+// picking it up if it was abandoned.
+func pendingRead(reader io.Reader) (*backgroundRead, bool) {
+	backgroundReadsMutex.Lock()
+	defer backgroundReadsMutex.Unlock()
+
+	read, found := backgroundReads[reader]
+	if found {
+		read.abandoned = false
+	}
+
+	return read, found
+}
+
+// abandonRead is called once an interaction is done with the reader. What
+// the reader's background read has read but nobody has taken is dropped,
+// either now or once the read completes, unless another interaction picks
+// the read up first.
+func abandonRead(reader io.Reader) {
+	backgroundReadsMutex.Lock()
+	defer backgroundReadsMutex.Unlock()
+
+	read, found := backgroundReads[reader]
+	if !found {
+		return
+	}
+
+	if read.finished {
+		delete(backgroundReads, reader)
+	} else {
+		read.abandoned = true
+	}
+}
+
+// startRead starts a read of up to size bytes from the reader in the
+// background.
+func startRead(reader io.Reader, size int) *backgroundRead {
+	read := &backgroundRead{
+		reader: reader,
+		done:   make(chan struct{}),
+	}
+
+	if reflect.TypeOf(reader).Comparable() {
+		backgroundReadsMutex.Lock()
+		backgroundReads[reader] = read
+		backgroundReadsMutex.Unlock()
+	}
+
+	go func() {
+		buf := make([]byte, size)
+		n, err := reader.Read(buf)
+
+		backgroundReadsMutex.Lock()
+		read.buf, read.err = buf[:n], err
+		read.finished = true
+
+		if read.abandoned && backgroundReads[reader] == read {
+			delete(backgroundReads, reader)
+		}
+
+		backgroundReadsMutex.Unlock()
+
+		close(read.done)
+	}()
+
+	return read
+}
+
+// take copies what was read into p, forgetting the read once it has all been
+// taken.
+func (read *backgroundRead) take(p []byte) (int, error) {
+	backgroundReadsMutex.Lock()
+	defer backgroundReadsMutex.Unlock()
+
+	n := copy(p, read.buf)
+	read.buf = read.buf[n:]
+
+	if len(read.buf) > 0 {
+		return n, nil
+	}
+
+	if backgroundReads[read.reader] == read {
+		delete(backgroundReads, read.reader)
+	}
+
+	return n, read.err
+}
@@ -0,0 +1,185 @@
+package interact_test
+
+import (
+	"errors"
+	"io"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/vito/go-interact/interact"
+)
+
+var _ = Describe("Resolving with validation", func() {
+	notBlank := func(value interface{}) error {
+		if strings.TrimSpace(value.(string)) == "" {
+			return errors.New("must not be blank")
+		}
+
+		return nil
+	}
+
+	Context("when the destination is a string", func() {
+		Context("when the default is invalid", func() {
+			BeforeEach(func() {
+				destination = strDst("")
+			})
+
+			DescribeTable("Resolve", (Example).Run,
+				Entry("when a valid string is entered", Example{
+					Prompt:   "some prompt",
+					Validate: notBlank,
+
+					Input: "forty two\n",
+
+					ExpectedAnswer: "forty two",
+					ExpectedOutput: "some prompt (): forty two\n",
+				}),
+
+				Entry("when a blank line is entered, followed by EOF", Example{
+					Prompt:   "some prompt",
+					Validate: notBlank,
+
+					Input: "\n",
+
+					ExpectedAnswer: "",
+					ExpectedErr:    io.EOF,
+					ExpectedOutput: "some prompt (): \ninvalid input (must not be blank)\nsome prompt (): ",
+				}),
+
+				Entry("when an invalid string is entered, followed by a valid string", Example{
+					Prompt:   "some prompt",
+					Validate: notBlank,
+
+					Input: "   \nforty two\n",
+
+					ExpectedAnswer: "forty two",
+					ExpectedOutput: "some prompt ():    \ninvalid input (must not be blank)\nsome prompt (): forty two\n",
+				}),
+			)
+		})
+
+		Context("when the default is valid", func() {
+			BeforeEach(func() {
+				destination = strDst("some default")
+			})
+
+			DescribeTable("Resolve", (Example).Run,
+				Entry("when a blank line is entered", Example{
+					Prompt:   "some prompt",
+					Validate: notBlank,
+
+					Input: "\n",
+
+					ExpectedAnswer: "some default",
+					ExpectedOutput: "some prompt (some default): \n",
+				}),
+
+				Entry("when an invalid string is entered, followed by a blank line", Example{
+					Prompt:   "some prompt",
+					Validate: notBlank,
+
+					Input: "   \n\n",
+
+					ExpectedAnswer: "some default",
+					ExpectedOutput: "some prompt (some default):    \ninvalid input (must not be blank)\nsome prompt (some default): \n",
+				}),
+			)
+		})
+
+		Context("when required", func() {
+			BeforeEach(func() {
+				destination = interact.Required(strDst(""))
+			})
+
+			DescribeTable("Resolve", (Example).Run,
+				Entry("when a blank line is entered, followed by an invalid string, followed by a valid string", Example{
+					Prompt:   "some prompt",
+					Validate: notBlank,
+
+					Input: "\n   \nforty two\n",
+
+					ExpectedAnswer: "forty two",
+					ExpectedOutput: "some prompt: \nsome prompt:    \ninvalid input (must not be blank)\nsome prompt: forty two\n",
+				}),
+			)
+		})
+	})
+
+	Context("when the destination is an int", func() {
+		validPort := func(value interface{}) error {
+			if value.(int) < 1 || value.(int) > 65535 {
+				return errors.New("must be 1-65535")
+			}
+
+			return nil
+		}
+
+		BeforeEach(func() {
+			destination = intDst(8080)
+		})
+
+		DescribeTable("Resolve", (Example).Run,
+			Entry("when a valid integer is entered", Example{
+				Prompt:   "some prompt",
+				Validate: validPort,
+
+				Input: "443\n",
+
+				ExpectedAnswer: 443,
+				ExpectedOutput: "some prompt (8080): 443\n",
+			}),
+
+			Entry("when a non-integer is entered, followed by an invalid integer, followed by a valid integer", Example{
+				Prompt:   "some prompt",
+				Validate: validPort,
+
+				Input: "foo\n0\n443\n",
+
+				ExpectedAnswer: 443,
+				ExpectedOutput: "some prompt (8080): foo\ninvalid input (not a number)\nsome prompt (8080): 0\ninvalid input (must be 1-65535)\nsome prompt (8080): 443\n",
+			}),
+		)
+	})
+
+	Context("when resolving from a set of choices", func() {
+		notDos := func(value interface{}) error {
+			if value == (arbitrary{"dos"}) {
+				return errors.New("dos is not allowed")
+			}
+
+			return nil
+		}
+
+		BeforeEach(func() {
+			choices = []interact.Choice{
+				{Display: "Uno", Value: arbitrary{"uno"}},
+				{Display: "Dos", Value: arbitrary{"dos"}},
+				{Display: "Tres", Value: arbitrary{"tres"}},
+			}
+
+			destination = arbDst(arbitrary{"dos"})
+		})
+
+		DescribeTable("Resolve", (Example).Run,
+			Entry("when a valid choice is entered", Example{
+				Prompt:   "some prompt",
+				Validate: notDos,
+
+				Input: "3\n",
+
+				ExpectedAnswer: arbitrary{"tres"},
+				ExpectedOutput: "1: Uno\n2: Dos\n3: Tres\nsome prompt (2): 3\n",
+			}),
+
+			Entry("when a blank line is entered, followed by a valid choice", Example{
+				Prompt:   "some prompt",
+				Validate: notDos,
+
+				Input: "\n1\n",
+
+				ExpectedAnswer: arbitrary{"uno"},
+				ExpectedOutput: "1: Uno\n2: Dos\n3: Tres\nsome prompt (2): \ninvalid selection (dos is not allowed)\nsome prompt (2): 1\n",
+			}),
+		)
+	})
+})